@@ -0,0 +1,18 @@
+// Command lilypad is the CLI entry point for running and developing
+// against the Lilypad network. The command tree itself lives in
+// pkg/cmd so it can be tested without building this binary.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bacalhau-project/lilypad/pkg/cmd"
+)
+
+func main() {
+	if err := cmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
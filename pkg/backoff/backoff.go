@@ -0,0 +1,89 @@
+// Package backoff is the exponential-backoff-with-jitter reconnect loop
+// shared by every client that keeps a long-lived connection to the solver
+// (pkg/resourceprovider, pkg/jobcreator).
+package backoff
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// Policy configures a reconnect loop's wait between attempts.
+type Policy struct {
+	// Min is the wait before the first retry.
+	Min time.Duration
+	// Max caps the wait between retries.
+	Max time.Duration
+	// MaxRetries bounds how many times Run will retry before giving up and
+	// returning the last error. Zero means retry forever.
+	MaxRetries int
+}
+
+// DefaultPolicy is the 50ms-to-10s, unbounded-retry policy used unless a
+// caller overrides it through options.
+var DefaultPolicy = Policy{
+	Min: 50 * time.Millisecond,
+	Max: 10 * time.Second,
+}
+
+// OnRetry is called after a failed attempt, before the loop sleeps for
+// wait, so callers can log the failure.
+type OnRetry func(attempt int, err error, wait time.Duration)
+
+// Run calls attempt repeatedly until it returns nil, ctx is done, or the
+// policy's MaxRetries is exceeded. attempt is expected to block for as
+// long as the connection stays up and only return once it has dropped, so
+// a nil return from attempt is treated as "closed deliberately" and ends
+// the loop without retrying.
+func (p Policy) Run(ctx context.Context, attempt func(ctx context.Context) error, onRetry OnRetry) error {
+	for try := 0; ; try++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		err := attempt(ctx)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if p.MaxRetries > 0 && try >= p.MaxRetries {
+			return err
+		}
+
+		wait := p.wait(try)
+		if onRetry != nil {
+			onRetry(try+1, err, wait)
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// wait returns the backoff duration for the given (zero-based) retry
+// count, doubling from Min up to Max and then picking a random wait
+// between half of that value and all of it.
+func (p Policy) wait(try int) time.Duration {
+	min := p.Min
+	if min <= 0 {
+		min = DefaultPolicy.Min
+	}
+	max := p.Max
+	if max <= 0 {
+		max = DefaultPolicy.Max
+	}
+
+	base := min << try // min * 2^try
+	if base <= 0 || base > max {
+		base = max
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(base)/2 + 1))
+	return base/2 + jitter
+}
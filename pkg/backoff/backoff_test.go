@@ -0,0 +1,58 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunRetriesUntilSuccess(t *testing.T) {
+	policy := Policy{Min: time.Millisecond, Max: 5 * time.Millisecond}
+
+	attempts := 0
+	err := policy.Run(context.Background(), func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("not yet")
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestRunStopsAtMaxRetries(t *testing.T) {
+	policy := Policy{Min: time.Millisecond, Max: 5 * time.Millisecond, MaxRetries: 2}
+
+	attempts := 0
+	wantErr := errors.New("always fails")
+	err := policy.Run(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return wantErr
+	}, nil)
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("Run error = %v, want %v", err, wantErr)
+	}
+	if attempts != 3 {
+		t.Fatalf("attempts = %d, want 3 (initial + 2 retries)", attempts)
+	}
+}
+
+func TestRunStopsOnContextCancel(t *testing.T) {
+	policy := Policy{Min: time.Hour, Max: time.Hour}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := policy.Run(ctx, func(ctx context.Context) error {
+		return errors.New("should not matter")
+	}, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/bacalhau-project/lilypad/pkg/devstack"
+)
+
+// NewDevstackCmd returns the "lilypad devstack" command group.
+func NewDevstackCmd() *cobra.Command {
+	devstackCmd := &cobra.Command{
+		Use:   "devstack",
+		Short: "Generate and manage a local single-host lilypad dev stack",
+	}
+
+	devstackCmd.AddCommand(newDevstackUpCmd())
+
+	return devstackCmd
+}
+
+func newDevstackUpCmd() *cobra.Command {
+	var dryRun bool
+	var print bool
+	var dir string
+
+	up := &cobra.Command{
+		Use:   "up",
+		Short: "Write (or update) a local dev stack's config and docker-compose.yaml",
+		Long: `up materializes the same solver/resource-provider/job-creator wiring
+test/integration_test.go's TestStack sets up in-process into a persistent
+~/.lilypad/stack.yaml plus a docker-compose.yaml alongside it.
+
+If a stack.yaml already exists, its rpc url and every component that has a
+private key are kept as-is; a component with no private key is replaced
+whole by a freshly generated one, so re-running "up" never rotates an
+already-funded dev wallet out from under you.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDevstackUp(cmd.OutOrStdout(), dir, dryRun, print)
+		},
+	}
+
+	up.Flags().BoolVar(&dryRun, "dry-run", false, "print what would be written without touching disk")
+	up.Flags().BoolVar(&print, "print", false, "print the resulting stack.yaml to stdout")
+	up.Flags().StringVar(&dir, "dir", "", "directory to write stack.yaml and docker-compose.yaml into (default ~/.lilypad)")
+
+	return up
+}
+
+func runDevstackUp(out io.Writer, dir string, dryRun, print bool) error {
+	if dir == "" {
+		defaultDir, err := devstack.DefaultDir()
+		if err != nil {
+			return err
+		}
+		dir = defaultDir
+	}
+
+	existing, err := devstack.Load(dir)
+	if err != nil {
+		return err
+	}
+
+	fresh, err := devstack.New()
+	if err != nil {
+		return fmt.Errorf("devstack: %w", err)
+	}
+
+	stack := devstack.Merge(existing, fresh)
+
+	if !dryRun {
+		if err := devstack.Write(dir, stack); err != nil {
+			return err
+		}
+	}
+
+	if print {
+		data, err := yaml.Marshal(stack)
+		if err != nil {
+			return fmt.Errorf("devstack: rendering stack.yaml: %w", err)
+		}
+		fmt.Fprint(out, string(data))
+	}
+
+	if dryRun {
+		fmt.Fprintf(out, "dry run: would write %s/%s and %s/%s\n", dir, devstack.ConfigFileName, dir, devstack.ComposeFileName)
+	} else {
+		fmt.Fprintf(out, "wrote %s/%s and %s/%s\n", dir, devstack.ConfigFileName, dir, devstack.ComposeFileName)
+	}
+
+	for _, line := range stack.Endpoints() {
+		fmt.Fprintln(out, line)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bacalhau-project/lilypad/pkg/devstack"
+)
+
+func TestDevstackUpWritesStackAndCompose(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "lilypad")
+	var out bytes.Buffer
+
+	if err := runDevstackUp(&out, dir, false, false); err != nil {
+		t.Fatalf("runDevstackUp: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, devstack.ConfigFileName)); err != nil {
+		t.Fatalf("stat %s: %v", devstack.ConfigFileName, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, devstack.ComposeFileName)); err != nil {
+		t.Fatalf("stat %s: %v", devstack.ComposeFileName, err)
+	}
+}
+
+func TestDevstackUpDryRunWritesNothing(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "lilypad")
+	var out bytes.Buffer
+
+	if err := runDevstackUp(&out, dir, true, true); err != nil {
+		t.Fatalf("runDevstackUp: %v", err)
+	}
+
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Fatalf("dry run created %s", dir)
+	}
+	if out.Len() == 0 {
+		t.Fatal("--print with dry-run produced no output")
+	}
+}
+
+func TestDevstackUpPreservesExistingStackOnRerun(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "lilypad")
+	var out bytes.Buffer
+
+	if err := runDevstackUp(&out, dir, false, false); err != nil {
+		t.Fatalf("first runDevstackUp: %v", err)
+	}
+	first, err := devstack.Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if err := runDevstackUp(&out, dir, false, false); err != nil {
+		t.Fatalf("second runDevstackUp: %v", err)
+	}
+	second, err := devstack.Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if first != second {
+		t.Fatalf("re-running devstack up rotated the stack: %+v != %+v", first, second)
+	}
+}
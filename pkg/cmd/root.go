@@ -0,0 +1,27 @@
+// Package cmd wires up the "lilypad" CLI's commands. cmd/lilypad/main.go
+// is a thin entry point that calls Execute; everything else lives here
+// so the command tree stays testable without an actual binary.
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewRootCmd constructs the top-level "lilypad" command and attaches its
+// subcommands.
+func NewRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "lilypad",
+		Short: "lilypad is the CLI for running and developing against the Lilypad network",
+	}
+
+	root.AddCommand(NewDevstackCmd())
+
+	return root
+}
+
+// Execute runs the "lilypad" CLI, returning any error the invoked
+// subcommand produced.
+func Execute() error {
+	return NewRootCmd().Execute()
+}
@@ -0,0 +1,42 @@
+// Package datatx defines the pluggable data-transfer contract job inputs
+// and results move through. A Manager owns one way of getting a file into
+// and out of somewhere addressable: a plain HTTP endpoint, a resumable
+// tus upload, IPFS, or an S3-compatible bucket. Callers select a Manager
+// by Kind at the point a deal is struck, so a job creator and resource
+// provider that agree on a Kind never need to agree on anything else.
+package datatx
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrUnsupportedKind is returned by a Registry when asked for a Kind it
+// has no Manager configured for.
+var ErrUnsupportedKind = errors.New("datatx: unsupported kind")
+
+// Ref is an opaque pointer to data held by whichever Manager produced it.
+// It is safe to persist (e.g. in a solver.Job's Inputs) and hand back to
+// a Manager of the same Kind on another process entirely.
+type Ref struct {
+	// Kind identifies the Manager that can resolve Location, e.g.
+	// "simple", "tus", "ipfs" or "s3".
+	Kind string
+	// Location is Manager-specific: a URL for simple/tus, a CID for ipfs,
+	// a bucket key for s3.
+	Location string
+}
+
+// Manager moves a single file into and out of one kind of backing store.
+type Manager interface {
+	// Kind returns the driver name this Manager implements, matching the
+	// Kind it stamps onto every Ref it Publishes.
+	Kind() string
+	// Publish uploads the file at localPath and returns a Ref that any
+	// Manager of the same Kind can later Fetch.
+	Publish(ctx context.Context, localPath string) (Ref, error)
+	// Fetch downloads the data behind ref to a new file under dir and
+	// returns its path. It returns an error if ref.Kind does not match
+	// Kind().
+	Fetch(ctx context.Context, ref Ref, dir string) (string, error)
+}
@@ -0,0 +1,49 @@
+// Package datatxfactory builds a datatx.Registry from options.DataTxOptions,
+// the way pkg/solver/storefactory builds a solver.Store from
+// options.StoreOptions.
+package datatxfactory
+
+import (
+	"fmt"
+
+	"github.com/bacalhau-project/lilypad/pkg/datatx"
+	"github.com/bacalhau-project/lilypad/pkg/datatx/ipfs"
+	"github.com/bacalhau-project/lilypad/pkg/datatx/s3"
+	"github.com/bacalhau-project/lilypad/pkg/datatx/simple"
+	"github.com/bacalhau-project/lilypad/pkg/datatx/tus"
+	"github.com/bacalhau-project/lilypad/pkg/options"
+)
+
+// NewRegistry builds a datatx.Registry from dataTxOptions. Unlike
+// storefactory.NewStore, it does not pick a single backend: every
+// sub-option with a non-empty endpoint is wired in, since the Registry's
+// whole point is letting a deal pick its transfer kind independently of
+// how the process is configured.
+func NewRegistry(dataTxOptions options.DataTxOptions) (*datatx.Registry, error) {
+	var managers []datatx.Manager
+
+	if dataTxOptions.Simple.BaseURL != "" {
+		managers = append(managers, simple.NewManager(dataTxOptions.Simple.BaseURL))
+	}
+	if dataTxOptions.Tus.BaseURL != "" {
+		managers = append(managers, tus.NewManager(dataTxOptions.Tus.BaseURL))
+	}
+	if dataTxOptions.IPFS.APIAddress != "" {
+		managers = append(managers, ipfs.NewManager(dataTxOptions.IPFS.APIAddress))
+	}
+	if dataTxOptions.S3.Endpoint != "" {
+		s3Manager, err := s3.NewManager(
+			dataTxOptions.S3.Endpoint,
+			dataTxOptions.S3.Bucket,
+			dataTxOptions.S3.AccessKeyID,
+			dataTxOptions.S3.SecretAccessKey,
+			dataTxOptions.S3.UseSSL,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("datatxfactory: %w", err)
+		}
+		managers = append(managers, s3Manager)
+	}
+
+	return datatx.NewRegistry(dataTxOptions.Default, managers...), nil
+}
@@ -0,0 +1,61 @@
+// Package datatxtest is the shared conformance suite every datatx.Manager
+// backend must pass. It is kept out of the datatx package itself so that
+// a plain testing import doesn't leak into production binaries.
+package datatxtest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bacalhau-project/lilypad/pkg/datatx"
+)
+
+// RunConformanceTests exercises the Manager contract against newManager,
+// which must return a fresh backend instance each time it is called.
+// Every backend under pkg/datatx/ should have a _test.go that calls this
+// from a TestXxx function so the suite only has to be written once.
+func RunConformanceTests(t *testing.T, newManager func(t *testing.T) datatx.Manager) {
+	ctx := context.Background()
+
+	t.Run("publish and fetch round trip", func(t *testing.T) {
+		m := newManager(t)
+
+		srcDir := t.TempDir()
+		srcPath := filepath.Join(srcDir, "input.bin")
+		want := []byte("lilypad job payload")
+		if err := os.WriteFile(srcPath, want, 0600); err != nil {
+			t.Fatalf("write source file: %v", err)
+		}
+
+		ref, err := m.Publish(ctx, srcPath)
+		if err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+		if ref.Kind != m.Kind() {
+			t.Fatalf("Ref.Kind = %q, want %q", ref.Kind, m.Kind())
+		}
+
+		gotPath, err := m.Fetch(ctx, ref, t.TempDir())
+		if err != nil {
+			t.Fatalf("Fetch: %v", err)
+		}
+		got, err := os.ReadFile(gotPath)
+		if err != nil {
+			t.Fatalf("read fetched file: %v", err)
+		}
+		if string(got) != string(want) {
+			t.Fatalf("fetched content = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("fetch rejects a ref of another kind", func(t *testing.T) {
+		m := newManager(t)
+
+		_, err := m.Fetch(ctx, datatx.Ref{Kind: "not-" + m.Kind(), Location: "anything"}, t.TempDir())
+		if err == nil {
+			t.Fatal("Fetch with a mismatched ref kind should fail")
+		}
+	})
+}
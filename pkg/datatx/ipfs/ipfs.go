@@ -0,0 +1,118 @@
+// Package ipfs is a datatx.Manager backed by an IPFS node's HTTP API, for
+// content-addressed distribution where the same input is reused across
+// many jobs and resource providers benefit from deduplication and local
+// caching.
+package ipfs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/bacalhau-project/lilypad/pkg/datatx"
+)
+
+// Manager is a datatx.Manager that adds files to, and cats them back
+// from, an IPFS node's HTTP API.
+type Manager struct {
+	// APIAddress is the node's API base, e.g. "http://localhost:5001".
+	APIAddress string
+	client     *http.Client
+}
+
+// NewManager returns a Manager that talks to the IPFS node at apiAddress
+// using http.DefaultClient.
+func NewManager(apiAddress string) *Manager {
+	return &Manager{APIAddress: apiAddress, client: http.DefaultClient}
+}
+
+func (m *Manager) Kind() string {
+	return "ipfs"
+}
+
+func (m *Manager) Publish(ctx context.Context, localPath string) (datatx.Ref, error) {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return datatx.Ref{}, fmt.Errorf("ipfs: open %s: %w", localPath, err)
+	}
+	defer f.Close()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filepath.Base(localPath))
+	if err != nil {
+		return datatx.Ref{}, fmt.Errorf("ipfs: build multipart body: %w", err)
+	}
+	if _, err := io.Copy(part, f); err != nil {
+		return datatx.Ref{}, fmt.Errorf("ipfs: read %s: %w", localPath, err)
+	}
+	if err := writer.Close(); err != nil {
+		return datatx.Ref{}, fmt.Errorf("ipfs: close multipart body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.APIAddress+"/api/v0/add", &body)
+	if err != nil {
+		return datatx.Ref{}, fmt.Errorf("ipfs: build add request: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return datatx.Ref{}, fmt.Errorf("ipfs: add: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return datatx.Ref{}, fmt.Errorf("ipfs: add: unexpected status %s", resp.Status)
+	}
+
+	var added struct {
+		Hash string `json:"Hash"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&added); err != nil {
+		return datatx.Ref{}, fmt.Errorf("ipfs: decode add response: %w", err)
+	}
+	if added.Hash == "" {
+		return datatx.Ref{}, fmt.Errorf("ipfs: add response missing Hash")
+	}
+
+	return datatx.Ref{Kind: m.Kind(), Location: added.Hash}, nil
+}
+
+func (m *Manager) Fetch(ctx context.Context, ref datatx.Ref, dir string) (string, error) {
+	if ref.Kind != m.Kind() {
+		return "", fmt.Errorf("ipfs: ref kind %q does not match manager kind %q", ref.Kind, m.Kind())
+	}
+
+	catURL := m.APIAddress + "/api/v0/cat?arg=" + url.QueryEscape(ref.Location)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, catURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("ipfs: build cat request: %w", err)
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("ipfs: cat %s: %w", ref.Location, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return "", fmt.Errorf("ipfs: cat %s: unexpected status %s", ref.Location, resp.Status)
+	}
+
+	localPath := filepath.Join(dir, ref.Location)
+	out, err := os.Create(localPath)
+	if err != nil {
+		return "", fmt.Errorf("ipfs: create %s: %w", localPath, err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return "", fmt.Errorf("ipfs: write %s: %w", localPath, err)
+	}
+	return localPath, nil
+}
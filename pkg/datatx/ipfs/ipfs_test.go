@@ -0,0 +1,73 @@
+package ipfs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/bacalhau-project/lilypad/pkg/datatx"
+	"github.com/bacalhau-project/lilypad/pkg/datatx/datatxtest"
+)
+
+// newTestServer fakes enough of the IPFS HTTP API (/api/v0/add content-
+// addresses its upload, /api/v0/cat returns it by that address) to
+// exercise Manager end to end without a real IPFS node.
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	blobs := map[string][]byte{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v0/add", func(w http.ResponseWriter, r *http.Request) {
+		mr, err := r.MultipartReader()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		part, err := mr.NextPart()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, err := io.ReadAll(part)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		sum := sha256.Sum256(data)
+		cid := hex.EncodeToString(sum[:])
+		mu.Lock()
+		blobs[cid] = data
+		mu.Unlock()
+
+		fmt.Fprintf(w, `{"Hash":%q}`, cid)
+	})
+	mux.HandleFunc("/api/v0/cat", func(w http.ResponseWriter, r *http.Request) {
+		cid := r.URL.Query().Get("arg")
+		mu.Lock()
+		data, ok := blobs[cid]
+		mu.Unlock()
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestManagerConformance(t *testing.T) {
+	server := newTestServer(t)
+	datatxtest.RunConformanceTests(t, func(t *testing.T) datatx.Manager {
+		return NewManager(server.URL)
+	})
+}
@@ -0,0 +1,65 @@
+package datatx
+
+import "context"
+
+// Registry holds every Manager a process has been configured with, keyed
+// by Kind, plus the default to fall back to when a deal carries no
+// explicit "datatx_kind" metadata. JobCreator and ResourceProvider each
+// build one from their options.DataTxOptions and share it across every
+// deal they handle.
+type Registry struct {
+	managers map[string]Manager
+	def      string
+}
+
+// NewRegistry returns a Registry serving managers, defaulting unlabeled
+// selections to def. def must name one of managers, or Default will
+// return ErrUnsupportedKind.
+func NewRegistry(def string, managers ...Manager) *Registry {
+	byKind := make(map[string]Manager, len(managers))
+	for _, m := range managers {
+		byKind[m.Kind()] = m
+	}
+	return &Registry{managers: byKind, def: def}
+}
+
+// For returns the Manager registered under kind, or ErrUnsupportedKind if
+// none is.
+func (r *Registry) For(kind string) (Manager, error) {
+	m, ok := r.managers[kind]
+	if !ok {
+		return nil, ErrUnsupportedKind
+	}
+	return m, nil
+}
+
+// Default returns the Manager configured as this Registry's fallback.
+func (r *Registry) Default() (Manager, error) {
+	return r.For(r.def)
+}
+
+// Publish uploads localPath via the Manager named kind, or the Registry's
+// default if kind is empty.
+func (r *Registry) Publish(ctx context.Context, kind, localPath string) (Ref, error) {
+	m, err := r.resolve(kind)
+	if err != nil {
+		return Ref{}, err
+	}
+	return m.Publish(ctx, localPath)
+}
+
+// Fetch downloads ref via the Manager matching ref.Kind.
+func (r *Registry) Fetch(ctx context.Context, ref Ref, dir string) (string, error) {
+	m, err := r.For(ref.Kind)
+	if err != nil {
+		return "", err
+	}
+	return m.Fetch(ctx, ref, dir)
+}
+
+func (r *Registry) resolve(kind string) (Manager, error) {
+	if kind == "" {
+		return r.Default()
+	}
+	return r.For(kind)
+}
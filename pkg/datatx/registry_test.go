@@ -0,0 +1,62 @@
+package datatx
+
+import (
+	"context"
+	"testing"
+)
+
+type stubManager struct {
+	kind string
+}
+
+func (m stubManager) Kind() string { return m.kind }
+func (m stubManager) Publish(ctx context.Context, localPath string) (Ref, error) {
+	return Ref{Kind: m.kind, Location: localPath}, nil
+}
+func (m stubManager) Fetch(ctx context.Context, ref Ref, dir string) (string, error) {
+	return ref.Location, nil
+}
+
+func TestRegistryForReturnsConfiguredManager(t *testing.T) {
+	registry := NewRegistry("simple", stubManager{kind: "simple"}, stubManager{kind: "ipfs"})
+
+	m, err := registry.For("ipfs")
+	if err != nil {
+		t.Fatalf("For(ipfs): %v", err)
+	}
+	if m.Kind() != "ipfs" {
+		t.Fatalf("For(ipfs).Kind() = %q, want ipfs", m.Kind())
+	}
+}
+
+func TestRegistryForUnknownKindReturnsErrUnsupportedKind(t *testing.T) {
+	registry := NewRegistry("simple", stubManager{kind: "simple"})
+
+	if _, err := registry.For("s3"); err != ErrUnsupportedKind {
+		t.Fatalf("For(s3) error = %v, want ErrUnsupportedKind", err)
+	}
+}
+
+func TestRegistryPublishFallsBackToDefault(t *testing.T) {
+	registry := NewRegistry("simple", stubManager{kind: "simple"}, stubManager{kind: "ipfs"})
+
+	ref, err := registry.Publish(context.Background(), "", "input.bin")
+	if err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if ref.Kind != "simple" {
+		t.Fatalf("Publish with no kind used %q, want the default simple", ref.Kind)
+	}
+}
+
+func TestRegistryFetchUsesRefKind(t *testing.T) {
+	registry := NewRegistry("simple", stubManager{kind: "simple"}, stubManager{kind: "ipfs"})
+
+	path, err := registry.Fetch(context.Background(), Ref{Kind: "ipfs", Location: "QmExample"}, t.TempDir())
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if path != "QmExample" {
+		t.Fatalf("Fetch = %q, want QmExample", path)
+	}
+}
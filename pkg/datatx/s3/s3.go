@@ -0,0 +1,74 @@
+// Package s3 is a datatx.Manager backed by any S3-compatible object
+// store (AWS S3, MinIO, etc.), for deployments that already have a
+// bucket and want job inputs/results to land there directly.
+package s3
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/bacalhau-project/lilypad/pkg/datatx"
+)
+
+// Manager is a datatx.Manager that puts and gets objects in a single
+// bucket of an S3-compatible store.
+type Manager struct {
+	Bucket string
+	client *minio.Client
+}
+
+// NewManager returns a Manager backed by the bucket at endpoint, using
+// static credentials. useSSL selects https vs http against endpoint.
+func NewManager(endpoint, bucket, accessKeyID, secretAccessKey string, useSSL bool) (*Manager, error) {
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKeyID, secretAccessKey, ""),
+		Secure: useSSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("s3: new client for %s: %w", endpoint, err)
+	}
+	return &Manager{Bucket: bucket, client: client}, nil
+}
+
+func (m *Manager) Kind() string {
+	return "s3"
+}
+
+func (m *Manager) Publish(ctx context.Context, localPath string) (datatx.Ref, error) {
+	key, err := newObjectKey()
+	if err != nil {
+		return datatx.Ref{}, fmt.Errorf("s3: generating object key: %w", err)
+	}
+
+	if _, err := m.client.FPutObject(ctx, m.Bucket, key, localPath, minio.PutObjectOptions{}); err != nil {
+		return datatx.Ref{}, fmt.Errorf("s3: put %s/%s: %w", m.Bucket, key, err)
+	}
+
+	return datatx.Ref{Kind: m.Kind(), Location: key}, nil
+}
+
+func (m *Manager) Fetch(ctx context.Context, ref datatx.Ref, dir string) (string, error) {
+	if ref.Kind != m.Kind() {
+		return "", fmt.Errorf("s3: ref kind %q does not match manager kind %q", ref.Kind, m.Kind())
+	}
+
+	localPath := filepath.Join(dir, filepath.Base(ref.Location))
+	if err := m.client.FGetObject(ctx, m.Bucket, ref.Location, localPath, minio.GetObjectOptions{}); err != nil {
+		return "", fmt.Errorf("s3: get %s/%s: %w", m.Bucket, ref.Location, err)
+	}
+	return localPath, nil
+}
+
+func newObjectKey() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,105 @@
+// Package simple is the baseline datatx.Manager: it PUTs a file to a
+// generated URL under a base endpoint and GETs it back. No resumability,
+// no content addressing, just a plain HTTP object store.
+package simple
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/bacalhau-project/lilypad/pkg/datatx"
+)
+
+// Manager is a datatx.Manager that stores objects as plain files behind
+// an HTTP PUT/GET endpoint, e.g. an nginx dav module or a signed-URL
+// proxy in front of a bucket.
+type Manager struct {
+	// BaseURL is the endpoint objects are PUT/GET under, e.g.
+	// "http://localhost:9000/objects".
+	BaseURL string
+	client  *http.Client
+}
+
+// NewManager returns a Manager that talks to baseURL using
+// http.DefaultClient.
+func NewManager(baseURL string) *Manager {
+	return &Manager{BaseURL: baseURL, client: http.DefaultClient}
+}
+
+func (m *Manager) Kind() string {
+	return "simple"
+}
+
+func (m *Manager) Publish(ctx context.Context, localPath string) (datatx.Ref, error) {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return datatx.Ref{}, fmt.Errorf("simple: open %s: %w", localPath, err)
+	}
+	defer f.Close()
+
+	id, err := newObjectID()
+	if err != nil {
+		return datatx.Ref{}, fmt.Errorf("simple: generating object id: %w", err)
+	}
+	url := m.BaseURL + "/" + id
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, f)
+	if err != nil {
+		return datatx.Ref{}, fmt.Errorf("simple: build PUT request: %w", err)
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return datatx.Ref{}, fmt.Errorf("simple: PUT %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return datatx.Ref{}, fmt.Errorf("simple: PUT %s: unexpected status %s", url, resp.Status)
+	}
+
+	return datatx.Ref{Kind: m.Kind(), Location: url}, nil
+}
+
+func (m *Manager) Fetch(ctx context.Context, ref datatx.Ref, dir string) (string, error) {
+	if ref.Kind != m.Kind() {
+		return "", fmt.Errorf("simple: ref kind %q does not match manager kind %q", ref.Kind, m.Kind())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ref.Location, nil)
+	if err != nil {
+		return "", fmt.Errorf("simple: build GET request: %w", err)
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("simple: GET %s: %w", ref.Location, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return "", fmt.Errorf("simple: GET %s: unexpected status %s", ref.Location, resp.Status)
+	}
+
+	localPath := filepath.Join(dir, filepath.Base(ref.Location))
+	out, err := os.Create(localPath)
+	if err != nil {
+		return "", fmt.Errorf("simple: create %s: %w", localPath, err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return "", fmt.Errorf("simple: write %s: %w", localPath, err)
+	}
+	return localPath, nil
+}
+
+func newObjectID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,67 @@
+package simple
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/bacalhau-project/lilypad/pkg/datatx"
+	"github.com/bacalhau-project/lilypad/pkg/datatx/datatxtest"
+)
+
+// newTestServer fakes just enough of an HTTP object store (PUT stores the
+// body under its path, GET returns it) to exercise Manager end to end.
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			mu.Lock()
+			objects[r.URL.Path] = body
+			mu.Unlock()
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			mu.Lock()
+			body, ok := objects[r.URL.Path]
+			mu.Unlock()
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write(body)
+		default:
+			http.Error(w, "unsupported method", http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestManagerConformance(t *testing.T) {
+	server := newTestServer(t)
+	datatxtest.RunConformanceTests(t, func(t *testing.T) datatx.Manager {
+		return NewManager(server.URL)
+	})
+}
+
+func TestPublishRejectsMissingFile(t *testing.T) {
+	server := newTestServer(t)
+	m := NewManager(server.URL)
+
+	_, err := m.Publish(context.Background(), filepath.Join(t.TempDir(), "does-not-exist"))
+	if err == nil {
+		t.Fatal("Publish with a missing file should fail")
+	}
+}
@@ -0,0 +1,165 @@
+// Package tus is a datatx.Manager built on the tus resumable upload
+// protocol's Creation extension (https://tus.io/protocols/resumable-upload),
+// for moving large files like model weights in chunkSize pieces. Publish
+// does not resume an interrupted upload: a failed PATCH ends Publish with
+// an error, and calling Publish again creates a new upload that starts
+// from byte zero.
+package tus
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/bacalhau-project/lilypad/pkg/datatx"
+)
+
+const tusResumableVersion = "1.0.0"
+
+// chunkSize is the amount uploaded per PATCH request. A real deployment
+// would tune this to the link; this is a reasonable default for WAN
+// transfers.
+const chunkSize = 4 << 20 // 4MiB
+
+// Manager is a datatx.Manager that uploads via the tus Creation extension
+// and downloads over a plain GET, since tus itself has no download side.
+type Manager struct {
+	// BaseURL is the tus server's creation endpoint, e.g.
+	// "http://localhost:1080/files".
+	BaseURL string
+	client  *http.Client
+}
+
+// NewManager returns a Manager that talks to the tus server at baseURL
+// using http.DefaultClient.
+func NewManager(baseURL string) *Manager {
+	return &Manager{BaseURL: baseURL, client: http.DefaultClient}
+}
+
+func (m *Manager) Kind() string {
+	return "tus"
+}
+
+func (m *Manager) Publish(ctx context.Context, localPath string) (datatx.Ref, error) {
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return datatx.Ref{}, fmt.Errorf("tus: stat %s: %w", localPath, err)
+	}
+
+	location, err := m.create(ctx, info.Size())
+	if err != nil {
+		return datatx.Ref{}, err
+	}
+
+	f, err := os.Open(localPath)
+	if err != nil {
+		return datatx.Ref{}, fmt.Errorf("tus: open %s: %w", localPath, err)
+	}
+	defer f.Close()
+
+	var offset int64
+	buf := make([]byte, chunkSize)
+	for offset < info.Size() {
+		n, readErr := f.Read(buf)
+		if n > 0 {
+			newOffset, patchErr := m.patch(ctx, location, offset, buf[:n])
+			if patchErr != nil {
+				return datatx.Ref{}, patchErr
+			}
+			offset = newOffset
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return datatx.Ref{}, fmt.Errorf("tus: read %s: %w", localPath, readErr)
+		}
+	}
+
+	return datatx.Ref{Kind: m.Kind(), Location: location}, nil
+}
+
+func (m *Manager) create(ctx context.Context, size int64) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.BaseURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("tus: build creation request: %w", err)
+	}
+	req.Header.Set("Tus-Resumable", tusResumableVersion)
+	req.Header.Set("Upload-Length", strconv.FormatInt(size, 10))
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("tus: create upload: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("tus: create upload: unexpected status %s", resp.Status)
+	}
+
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return "", fmt.Errorf("tus: create upload: response missing Location header")
+	}
+	return location, nil
+}
+
+func (m *Manager) patch(ctx context.Context, location string, offset int64, chunk []byte) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, location, bytes.NewReader(chunk))
+	if err != nil {
+		return 0, fmt.Errorf("tus: build patch request: %w", err)
+	}
+	req.Header.Set("Tus-Resumable", tusResumableVersion)
+	req.Header.Set("Upload-Offset", strconv.FormatInt(offset, 10))
+	req.Header.Set("Content-Type", "application/offset+octet-stream")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("tus: patch %s: %w", location, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		return 0, fmt.Errorf("tus: patch %s: unexpected status %s", location, resp.Status)
+	}
+
+	newOffset, err := strconv.ParseInt(resp.Header.Get("Upload-Offset"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("tus: patch %s: invalid Upload-Offset in response: %w", location, err)
+	}
+	return newOffset, nil
+}
+
+func (m *Manager) Fetch(ctx context.Context, ref datatx.Ref, dir string) (string, error) {
+	if ref.Kind != m.Kind() {
+		return "", fmt.Errorf("tus: ref kind %q does not match manager kind %q", ref.Kind, m.Kind())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ref.Location, nil)
+	if err != nil {
+		return "", fmt.Errorf("tus: build GET request: %w", err)
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("tus: GET %s: %w", ref.Location, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return "", fmt.Errorf("tus: GET %s: unexpected status %s", ref.Location, resp.Status)
+	}
+
+	localPath := filepath.Join(dir, filepath.Base(ref.Location))
+	out, err := os.Create(localPath)
+	if err != nil {
+		return "", fmt.Errorf("tus: create %s: %w", localPath, err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return "", fmt.Errorf("tus: write %s: %w", localPath, err)
+	}
+	return localPath, nil
+}
@@ -0,0 +1,92 @@
+package tus
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/bacalhau-project/lilypad/pkg/datatx"
+	"github.com/bacalhau-project/lilypad/pkg/datatx/datatxtest"
+)
+
+// newTestServer fakes the minimum of the tus Creation extension (POST
+// allocates an upload ID and returns its Location, PATCH appends bytes at
+// Upload-Offset) plus a plain GET of the assembled file, to exercise
+// Manager end to end without a real tus server.
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	uploads := map[string][]byte{}
+	nextID := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/files", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "unsupported method", http.StatusMethodNotAllowed)
+			return
+		}
+		mu.Lock()
+		nextID++
+		id := strconv.Itoa(nextID)
+		uploads[id] = nil
+		mu.Unlock()
+
+		w.Header().Set("Location", "http://"+r.Host+"/files/"+id)
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/files/", func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Path[len("/files/"):]
+		switch r.Method {
+		case http.MethodPatch:
+			offset, err := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+			if err != nil {
+				http.Error(w, "bad offset", http.StatusBadRequest)
+				return
+			}
+			chunk, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			mu.Lock()
+			data := uploads[id]
+			if int64(len(data)) != offset {
+				mu.Unlock()
+				http.Error(w, "offset mismatch", http.StatusConflict)
+				return
+			}
+			data = append(data, chunk...)
+			uploads[id] = data
+			newOffset := len(data)
+			mu.Unlock()
+
+			w.Header().Set("Upload-Offset", strconv.Itoa(newOffset))
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			mu.Lock()
+			data, ok := uploads[id]
+			mu.Unlock()
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write(data)
+		default:
+			http.Error(w, "unsupported method", http.StatusMethodNotAllowed)
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestManagerConformance(t *testing.T) {
+	server := newTestServer(t)
+	datatxtest.RunConformanceTests(t, func(t *testing.T) datatx.Manager {
+		return NewManager(server.URL + "/files")
+	})
+}
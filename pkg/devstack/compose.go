@@ -0,0 +1,44 @@
+package devstack
+
+import "fmt"
+
+// RenderDockerCompose renders a docker-compose.yaml running stack's
+// solver, resource provider and job creator as three services against
+// an already-running dev chain at stack.RpcURL (devstack up does not
+// manage the chain itself, matching test/integration_test.go, which
+// expects one to already be listening).
+func RenderDockerCompose(stack Stack) string {
+	return fmt.Sprintf(`# Generated by "lilypad devstack up". Edit stack.yaml and re-run instead
+# of editing this file directly; it is overwritten on every run.
+services:
+  solver:
+    image: ghcr.io/bacalhau-project/lilypad/solver:latest
+    ports:
+      - "8080:8080"
+    environment:
+      WEB3_RPC_URL: %s
+      SOLVER_PRIVATE_KEY: %s
+
+  resource-provider:
+    image: ghcr.io/bacalhau-project/lilypad/resource-provider:latest
+    depends_on:
+      - solver
+    environment:
+      WEB3_RPC_URL: %s
+      RESOURCE_PROVIDER_PRIVATE_KEY: %s
+      SOLVER_URL: %s
+
+  job-creator:
+    image: ghcr.io/bacalhau-project/lilypad/job-creator:latest
+    depends_on:
+      - solver
+    environment:
+      WEB3_RPC_URL: %s
+      JOB_CREATOR_PRIVATE_KEY: %s
+      SOLVER_URL: %s
+`,
+		stack.RpcURL, stack.Solver.PrivateKey,
+		stack.RpcURL, stack.ResourceProvider.PrivateKey, stack.Solver.URL,
+		stack.RpcURL, stack.JobCreator.PrivateKey, stack.Solver.URL,
+	)
+}
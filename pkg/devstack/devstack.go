@@ -0,0 +1,118 @@
+// Package devstack generates and persists the local, single-host stack
+// (a solver, a resource provider and a job creator, each with their own
+// dev wallet) that the "lilypad devstack" command materializes so a new
+// contributor can run one command instead of exporting three private-key
+// env vars and hand-rolling the wiring test/integration_test.go's
+// TestStack does in-process.
+package devstack
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ComponentConfig is one process's slice of a Stack: the dev wallet it
+// should run as. The only endpoint a component carries is the solver's
+// own URL, on SolverConfig.
+type ComponentConfig struct {
+	PrivateKey string `yaml:"private_key"`
+	Address    string `yaml:"address"`
+}
+
+// SolverConfig is the solver's slice of a Stack.
+type SolverConfig struct {
+	ComponentConfig `yaml:",inline"`
+	URL             string `yaml:"url"`
+}
+
+// Stack is the full set of dev wallets and endpoints "lilypad devstack
+// up" writes to ~/.lilypad/stack.yaml. It is the config-file analogue of
+// what test/integration_test.go's TestStack wires up in-process.
+type Stack struct {
+	RpcURL           string          `yaml:"rpc_url"`
+	Solver           SolverConfig    `yaml:"solver"`
+	ResourceProvider ComponentConfig `yaml:"resource_provider"`
+	JobCreator       ComponentConfig `yaml:"job_creator"`
+}
+
+// DefaultRpcURL is the web3 RPC endpoint a freshly generated Stack
+// assumes, matching options.NewSolverOptions's own default.
+const DefaultRpcURL = "http://localhost:8545"
+
+// DefaultSolverURL is the solver endpoint a freshly generated Stack
+// assumes, matching options.NewResourceProviderOptions's own default.
+const DefaultSolverURL = "ws://localhost:8080"
+
+// New generates a fresh Stack: a new dev wallet for each of the three
+// components, and the same default endpoints getSolver/getResourceProvider
+// /getJobCreator use in test/integration_test.go.
+func New() (Stack, error) {
+	solverKey, solverAddress, err := generateWallet()
+	if err != nil {
+		return Stack{}, fmt.Errorf("devstack: generating solver wallet: %w", err)
+	}
+	rpKey, rpAddress, err := generateWallet()
+	if err != nil {
+		return Stack{}, fmt.Errorf("devstack: generating resource provider wallet: %w", err)
+	}
+	jcKey, jcAddress, err := generateWallet()
+	if err != nil {
+		return Stack{}, fmt.Errorf("devstack: generating job creator wallet: %w", err)
+	}
+
+	return Stack{
+		RpcURL: DefaultRpcURL,
+		Solver: SolverConfig{
+			ComponentConfig: ComponentConfig{PrivateKey: solverKey, Address: solverAddress},
+			URL:             DefaultSolverURL,
+		},
+		ResourceProvider: ComponentConfig{PrivateKey: rpKey, Address: rpAddress},
+		JobCreator:       ComponentConfig{PrivateKey: jcKey, Address: jcAddress},
+	}, nil
+}
+
+// Merge fills in any zero-value component of base from overlay, without
+// touching a component base already has. It is how "lilypad devstack up"
+// avoids rotating wallets (and therefore addresses already funded on a
+// dev chain) every time it is re-run against an existing stack.yaml.
+func Merge(base, overlay Stack) Stack {
+	merged := base
+	if merged.RpcURL == "" {
+		merged.RpcURL = overlay.RpcURL
+	}
+	if merged.Solver.PrivateKey == "" {
+		merged.Solver = overlay.Solver
+	}
+	if merged.ResourceProvider.PrivateKey == "" {
+		merged.ResourceProvider = overlay.ResourceProvider
+	}
+	if merged.JobCreator.PrivateKey == "" {
+		merged.JobCreator = overlay.JobCreator
+	}
+	return merged
+}
+
+// Endpoints summarizes a Stack as the human-readable lines "lilypad
+// devstack up" prints once it has written the config.
+func (s Stack) Endpoints() []string {
+	return []string{
+		fmt.Sprintf("web3 rpc:          %s", s.RpcURL),
+		fmt.Sprintf("solver:            %s (address %s)", s.Solver.URL, s.Solver.Address),
+		fmt.Sprintf("resource provider: address %s", s.ResourceProvider.Address),
+		fmt.Sprintf("job creator:       address %s", s.JobCreator.Address),
+	}
+}
+
+func generateWallet() (privateKeyHex, address string, err error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return "", "", err
+	}
+	return hexPrivateKey(key), crypto.PubkeyToAddress(key.PublicKey).Hex(), nil
+}
+
+func hexPrivateKey(key *ecdsa.PrivateKey) string {
+	return fmt.Sprintf("%x", crypto.FromECDSA(key))
+}
@@ -0,0 +1,63 @@
+package devstack
+
+import "testing"
+
+func TestNewGeneratesDistinctWallets(t *testing.T) {
+	stack, err := New()
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	addresses := map[string]bool{
+		stack.Solver.Address:           true,
+		stack.ResourceProvider.Address: true,
+		stack.JobCreator.Address:       true,
+	}
+	if len(addresses) != 3 {
+		t.Fatalf("New produced %d distinct addresses, want 3", len(addresses))
+	}
+	if stack.RpcURL != DefaultRpcURL {
+		t.Fatalf("RpcURL = %q, want %q", stack.RpcURL, DefaultRpcURL)
+	}
+	if stack.Solver.URL != DefaultSolverURL {
+		t.Fatalf("Solver.URL = %q, want %q", stack.Solver.URL, DefaultSolverURL)
+	}
+}
+
+func TestMergeKeepsExistingWallets(t *testing.T) {
+	base := Stack{
+		RpcURL: DefaultRpcURL,
+		Solver: SolverConfig{
+			ComponentConfig: ComponentConfig{PrivateKey: "existing-key", Address: "existing-address"},
+			URL:             DefaultSolverURL,
+		},
+	}
+	overlay, err := New()
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	merged := Merge(base, overlay)
+
+	if merged.Solver.PrivateKey != "existing-key" {
+		t.Fatalf("Merge replaced an existing solver wallet: got %q", merged.Solver.PrivateKey)
+	}
+	if merged.ResourceProvider.PrivateKey != overlay.ResourceProvider.PrivateKey {
+		t.Fatalf("Merge did not fill in the missing resource provider wallet")
+	}
+	if merged.JobCreator.PrivateKey != overlay.JobCreator.PrivateKey {
+		t.Fatalf("Merge did not fill in the missing job creator wallet")
+	}
+}
+
+func TestEndpointsIncludesEachComponent(t *testing.T) {
+	stack, err := New()
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	endpoints := stack.Endpoints()
+	if len(endpoints) != 4 {
+		t.Fatalf("Endpoints returned %d lines, want 4", len(endpoints))
+	}
+}
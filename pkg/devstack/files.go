@@ -0,0 +1,67 @@
+package devstack
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileName is the stack config's filename under a devstack
+// directory (by default ~/.lilypad).
+const ConfigFileName = "stack.yaml"
+
+// ComposeFileName is the generated docker-compose file's name alongside
+// ConfigFileName.
+const ComposeFileName = "docker-compose.yaml"
+
+// DefaultDir returns ~/.lilypad, the default directory "lilypad devstack
+// up" reads and writes.
+func DefaultDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("devstack: resolving home directory: %w", err)
+	}
+	return filepath.Join(home, ".lilypad"), nil
+}
+
+// Load reads the Stack at dir/ConfigFileName. A missing file is not an
+// error: it returns the zero Stack, which Merge treats as "nothing to
+// keep".
+func Load(dir string) (Stack, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ConfigFileName))
+	if os.IsNotExist(err) {
+		return Stack{}, nil
+	}
+	if err != nil {
+		return Stack{}, fmt.Errorf("devstack: reading %s: %w", ConfigFileName, err)
+	}
+
+	var stack Stack
+	if err := yaml.Unmarshal(data, &stack); err != nil {
+		return Stack{}, fmt.Errorf("devstack: parsing %s: %w", ConfigFileName, err)
+	}
+	return stack, nil
+}
+
+// Write renders stack's config and docker-compose files into dir,
+// creating dir if necessary.
+func Write(dir string, stack Stack) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("devstack: creating %s: %w", dir, err)
+	}
+
+	configData, err := yaml.Marshal(stack)
+	if err != nil {
+		return fmt.Errorf("devstack: rendering %s: %w", ConfigFileName, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ConfigFileName), configData, 0600); err != nil {
+		return fmt.Errorf("devstack: writing %s: %w", ConfigFileName, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ComposeFileName), []byte(RenderDockerCompose(stack)), 0600); err != nil {
+		return fmt.Errorf("devstack: writing %s: %w", ComposeFileName, err)
+	}
+	return nil
+}
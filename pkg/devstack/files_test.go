@@ -0,0 +1,46 @@
+package devstack
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadMissingFileReturnsZeroStack(t *testing.T) {
+	stack, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if stack != (Stack{}) {
+		t.Fatalf("Load of an empty directory returned %+v, want the zero Stack", stack)
+	}
+}
+
+func TestWriteThenLoadRoundTrips(t *testing.T) {
+	dir := t.TempDir()
+	stack, err := New()
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if err := Write(dir, stack); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	got, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got != stack {
+		t.Fatalf("Load after Write = %+v, want %+v", got, stack)
+	}
+
+	composeData, err := os.ReadFile(filepath.Join(dir, ComposeFileName))
+	if err != nil {
+		t.Fatalf("read %s: %v", ComposeFileName, err)
+	}
+	if !strings.Contains(string(composeData), stack.Solver.PrivateKey) {
+		t.Fatalf("%s does not reference the solver's private key", ComposeFileName)
+	}
+}
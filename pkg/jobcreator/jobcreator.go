@@ -0,0 +1,36 @@
+// Package jobcreator implements the process that submits jobs to the
+// solver and collects results once a matched deal completes.
+package jobcreator
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/bacalhau-project/lilypad/pkg/datatx"
+	"github.com/bacalhau-project/lilypad/pkg/datatx/datatxfactory"
+	"github.com/bacalhau-project/lilypad/pkg/options"
+	"github.com/bacalhau-project/lilypad/pkg/web3"
+)
+
+// JobCreator submits jobs to the solver on behalf of an end user and
+// tracks them through to completion.
+type JobCreator struct {
+	options options.JobCreatorOptions
+	web3SDK *web3.ContractSDK
+	dataTx  *datatx.Registry
+	log     *slog.Logger
+}
+
+// NewJobCreator wires up a JobCreator against the given contract SDK.
+func NewJobCreator(jobCreatorOptions options.JobCreatorOptions, web3SDK *web3.ContractSDK) (*JobCreator, error) {
+	dataTx, err := datatxfactory.NewRegistry(jobCreatorOptions.DataTx)
+	if err != nil {
+		return nil, fmt.Errorf("jobcreator: %w", err)
+	}
+	return &JobCreator{
+		options: jobCreatorOptions,
+		web3SDK: web3SDK,
+		dataTx:  dataTx,
+		log:     slog.Default(),
+	}, nil
+}
@@ -0,0 +1,98 @@
+package jobcreator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/bacalhau-project/lilypad/pkg/backoff"
+	"github.com/bacalhau-project/lilypad/pkg/system"
+)
+
+// solverConn is the live websocket connection to the solver, used to
+// receive match/deal/result events. Jobs are not sent over it.
+type solverConn struct {
+	ws *websocket.Conn
+}
+
+func (jc *JobCreator) dialSolver(ctx context.Context) (*solverConn, error) {
+	ws, _, err := websocket.DefaultDialer.DialContext(ctx, jc.options.SolverURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("jobcreator: dial solver at %s: %w", jc.options.SolverURL, err)
+	}
+	return &solverConn{ws: ws}, nil
+}
+
+// runConnection owns a single connection's lifetime, reading solver
+// events until the socket errors, ctx is cancelled, or the connection is
+// closed deliberately (which returns nil so the reconnect supervisor does
+// not retry).
+func (jc *JobCreator) runConnection(ctx context.Context, conn *solverConn) error {
+	defer conn.ws.Close()
+	// Closing the socket once ctx is done unblocks ReadJSON below.
+	stop := context.AfterFunc(ctx, func() { conn.ws.Close() })
+	defer stop()
+
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+		var event map[string]any
+		if err := conn.ws.ReadJSON(&event); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("jobcreator: solver connection lost: %w", err)
+		}
+		// TODO: dispatch event to the tracked job's result channel. Until
+		// that exists, log so an operator can see a match/deal/result
+		// notification arrived instead of it vanishing with no trace.
+		jc.log.WarnContext(ctx, "received solver event with no dispatch target yet", "event", event)
+	}
+}
+
+// runReconnectLoop dials the solver and keeps the connection alive,
+// retrying with exponential backoff and jitter whenever it drops. It only
+// returns once ctx is done or the configured retry budget is exhausted.
+func (jc *JobCreator) runReconnectLoop(ctx context.Context) error {
+	policy := backoff.Policy{
+		Min:        jc.options.Reconnect.MinBackoff,
+		Max:        jc.options.Reconnect.MaxBackoff,
+		MaxRetries: jc.options.Reconnect.MaxRetries,
+	}
+
+	return policy.Run(ctx, func(ctx context.Context) error {
+		conn, err := jc.dialSolver(ctx)
+		if err != nil {
+			return err
+		}
+		return jc.runConnection(ctx, conn)
+	}, func(attempt int, err error, wait time.Duration) {
+		jc.log.WarnContext(ctx, "solver connection attempt failed, retrying", "attempt", attempt, "wait", wait, "error", err)
+	})
+}
+
+// Start dials the solver, wrapped in a backoff-and-retry reconnect loop,
+// so SubmitJob's results can be tracked even if the solver is briefly
+// unreachable. It returns immediately; the reconnect loop runs in the
+// background until systemContext.Ctx is done, at which point
+// systemContext.Cm's registered cleanup waits for it to exit.
+func (jc *JobCreator) Start(systemContext *system.CommandContext) error {
+	jc.log = systemContext.Logger("jobcreator")
+	jc.log.InfoContext(systemContext.Ctx, "starting job creator", "solver_url", jc.options.SolverURL)
+
+	ctx := systemContext.Ctx
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := jc.runReconnectLoop(ctx); err != nil && ctx.Err() == nil {
+			jc.log.ErrorContext(ctx, "reconnect loop exited", "error", err)
+		}
+	}()
+	systemContext.Cm.RegisterCallback(func() {
+		<-done
+	})
+	return nil
+}
@@ -0,0 +1,123 @@
+package jobcreator
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/bacalhau-project/lilypad/pkg/options"
+)
+
+// TestRunReconnectLoopSurvivesDroppedConnection spins up a fake solver
+// websocket that accepts a connection, sends one event, then drops the
+// connection - simulating a solver outage - and asserts the reconnect loop
+// dials again rather than exiting or wedging.
+func TestRunReconnectLoopSurvivesDroppedConnection(t *testing.T) {
+	var connectCount int32
+	eventsSent := make(chan struct{}, 4)
+
+	// liveConns tracks every upgraded connection so the test can close them
+	// from the server side once it has seen enough connections.
+	var mu sync.Mutex
+	var liveConns []*websocket.Conn
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		mu.Lock()
+		liveConns = append(liveConns, conn)
+		mu.Unlock()
+
+		n := atomic.AddInt32(&connectCount, 1)
+
+		if err := conn.WriteJSON(map[string]any{"type": "match"}); err != nil {
+			t.Errorf("server WriteJSON: %v", err)
+			return
+		}
+		eventsSent <- struct{}{}
+
+		if n == 1 {
+			// Drop the connection right after the first event, so the
+			// reconnect loop has to dial again.
+			return
+		}
+
+		// Stay open on later connections until the test forces it closed.
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	jc := &JobCreator{
+		options: options.JobCreatorOptions{
+			SolverURL: wsURL,
+			Reconnect: options.ReconnectOptions{
+				MinBackoff: time.Millisecond,
+				MaxBackoff: 10 * time.Millisecond,
+			},
+		},
+		log: slog.Default(),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- jc.runReconnectLoop(ctx)
+	}()
+
+	for sent := 0; sent < 2; sent++ {
+		select {
+		case <-eventsSent:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("solver did not see a reconnect after the drop, got %d events", sent)
+		}
+	}
+
+	// Close the still-open connection and the server before cancelling, so
+	// the loop exits with no solver left to redial.
+	mu.Lock()
+	for _, conn := range liveConns {
+		conn.Close()
+	}
+	mu.Unlock()
+	server.Close()
+
+	cancel()
+	select {
+	case err := <-done:
+		// runConnection treats "ctx done" the same as a deliberate close and
+		// returns nil, so Policy.Run may surface either nil or ctx.Err()
+		// depending on exactly where the cancellation was observed.
+		if err != nil && !errors.Is(err, context.Canceled) {
+			t.Fatalf("runReconnectLoop returned %v after ctx cancel, want nil or context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("runReconnectLoop did not exit after ctx cancel")
+	}
+
+	if got := atomic.LoadInt32(&connectCount); got < 2 {
+		t.Fatalf("solver saw %d connections, want at least 2", got)
+	}
+}
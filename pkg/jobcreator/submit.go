@@ -0,0 +1,77 @@
+package jobcreator
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/bacalhau-project/lilypad/pkg/datatx"
+	"github.com/bacalhau-project/lilypad/pkg/lilyctx"
+	"github.com/bacalhau-project/lilypad/pkg/resourceprovider"
+	"github.com/bacalhau-project/lilypad/pkg/solver"
+)
+
+// SubmitJobRequest describes a job to submit to the solver.
+type SubmitJobRequest struct {
+	Spec solver.Spec
+	// TargetIdentity, if set, pins the job to a single resource provider
+	// agent addressed as "<resource-provider-address>.<agent-name>". Leave
+	// empty to let the solver match against any compatible offer.
+	TargetIdentity string
+	// Inputs are local file paths published through DataTxKind (or the
+	// JobCreator's default datatx.Manager) before the job is submitted.
+	Inputs []string
+	// DataTxKind selects the pkg/datatx Manager kind ("simple", "tus",
+	// "ipfs" or "s3") used for this job's inputs and, later, its results.
+	// Empty defers to the JobCreator's configured default.
+	DataTxKind string
+}
+
+// SubmitJob publishes req's inputs through the JobCreator's datatx
+// registry, builds a solver.Job from req and returns it. It does not send
+// the job to the solver. The Job's ID is attached to ctx (see
+// pkg/lilyctx) so every log line emitted while submitting carries it.
+func (jc *JobCreator) SubmitJob(ctx context.Context, req SubmitJobRequest) (solver.Job, error) {
+	if req.TargetIdentity != "" {
+		if _, _, err := resourceprovider.ParseIdentity(req.TargetIdentity); err != nil {
+			return solver.Job{}, fmt.Errorf("jobcreator: %w", err)
+		}
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		return solver.Job{}, fmt.Errorf("jobcreator: generating job id: %w", err)
+	}
+	ctx = lilyctx.WithJobID(ctx, id)
+
+	inputRefs := make([]datatx.Ref, 0, len(req.Inputs))
+	for _, input := range req.Inputs {
+		ref, err := jc.dataTx.Publish(ctx, req.DataTxKind, input)
+		if err != nil {
+			return solver.Job{}, fmt.Errorf("jobcreator: publishing input %s: %w", input, err)
+		}
+		jc.log.InfoContext(ctx, "published job input", "input", input, "datatx_kind", ref.Kind, "location", ref.Location)
+		inputRefs = append(inputRefs, ref)
+	}
+
+	job := solver.Job{
+		ID:             id,
+		Spec:           req.Spec,
+		Creator:        jc.web3SDK.Address().Hex(),
+		State:          solver.JobStateCreated,
+		TargetIdentity: req.TargetIdentity,
+		DataTxKind:     req.DataTxKind,
+		Inputs:         inputRefs,
+	}
+	jc.log.InfoContext(ctx, "submitting job", "module", req.Spec.Module, "target_identity", req.TargetIdentity, "inputs", len(inputRefs))
+	return job, nil
+}
+
+func newJobID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
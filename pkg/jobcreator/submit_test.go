@@ -0,0 +1,93 @@
+package jobcreator
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bacalhau-project/lilypad/pkg/datatx"
+	"github.com/bacalhau-project/lilypad/pkg/options"
+	"github.com/bacalhau-project/lilypad/pkg/solver"
+	"github.com/bacalhau-project/lilypad/pkg/web3"
+)
+
+// testPrivateKey is Hardhat's well-known default account #0 key, used
+// only to derive a deterministic address for tests.
+const testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+// fakeManager is an in-memory datatx.Manager for exercising SubmitJob's
+// input-publishing path without a real backend.
+type fakeManager struct {
+	blobs map[string][]byte
+}
+
+func (m *fakeManager) Kind() string { return "fake" }
+
+func (m *fakeManager) Publish(ctx context.Context, localPath string) (datatx.Ref, error) {
+	data, err := os.ReadFile(localPath)
+	if err != nil {
+		return datatx.Ref{}, err
+	}
+	location := filepath.Base(localPath)
+	m.blobs[location] = data
+	return datatx.Ref{Kind: m.Kind(), Location: location}, nil
+}
+
+func (m *fakeManager) Fetch(ctx context.Context, ref datatx.Ref, dir string) (string, error) {
+	path := filepath.Join(dir, ref.Location)
+	return path, os.WriteFile(path, m.blobs[ref.Location], 0600)
+}
+
+func newTestJobCreator(t *testing.T, manager datatx.Manager) *JobCreator {
+	t.Helper()
+	web3SDK, err := web3.NewContractSDK(web3.Options{PrivateKey: testPrivateKey})
+	if err != nil {
+		t.Fatalf("NewContractSDK: %v", err)
+	}
+	return &JobCreator{
+		options: options.JobCreatorOptions{},
+		web3SDK: web3SDK,
+		dataTx:  datatx.NewRegistry(manager.Kind(), manager),
+		log:     slog.Default(),
+	}
+}
+
+func TestSubmitJobPublishesInputs(t *testing.T) {
+	manager := &fakeManager{blobs: map[string][]byte{}}
+	jc := newTestJobCreator(t, manager)
+	srcPath := filepath.Join(t.TempDir(), "input.bin")
+	if err := os.WriteFile(srcPath, []byte("payload"), 0600); err != nil {
+		t.Fatalf("write input file: %v", err)
+	}
+
+	job, err := jc.SubmitJob(context.Background(), SubmitJobRequest{
+		Spec:   solver.Spec{Module: "stable-diffusion"},
+		Inputs: []string{srcPath},
+	})
+	if err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	if job.ID == "" {
+		t.Fatal("SubmitJob returned a job with no ID")
+	}
+	if len(manager.blobs) != 1 {
+		t.Fatalf("manager has %d blobs, want 1", len(manager.blobs))
+	}
+	if len(job.Inputs) != 1 || job.Inputs[0].Kind != manager.Kind() {
+		t.Fatalf("job.Inputs = %+v, want one ref of kind %s", job.Inputs, manager.Kind())
+	}
+}
+
+func TestSubmitJobRejectsInvalidTargetIdentity(t *testing.T) {
+	manager := &fakeManager{blobs: map[string][]byte{}}
+	jc := newTestJobCreator(t, manager)
+	_, err := jc.SubmitJob(context.Background(), SubmitJobRequest{
+		Spec:           solver.Spec{Module: "stable-diffusion"},
+		TargetIdentity: "not-composite",
+	})
+	if err == nil {
+		t.Fatal("SubmitJob with an invalid target identity should have failed")
+	}
+}
@@ -0,0 +1,78 @@
+// Package lilyctx owns the typed context keys threaded through the
+// solver, resource provider, job creator and their HTTP/WS handlers. It
+// replaces ad-hoc string keys and struct fields so cross-cutting concerns
+// - per-request logging, distributed tracing - can be layered on without
+// every package importing every other package's types.
+package lilyctx
+
+import "context"
+
+type contextKey int
+
+const (
+	jobIDKey contextKey = iota
+	dealIDKey
+	resourceProviderKey
+	requestIDKey
+	authTokenKey
+)
+
+// WithJobID returns a copy of ctx carrying id as the current job ID.
+func WithJobID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, jobIDKey, id)
+}
+
+// JobID returns the job ID stored in ctx, if any.
+func JobID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(jobIDKey).(string)
+	return id, ok
+}
+
+// WithDealID returns a copy of ctx carrying id as the current deal ID.
+func WithDealID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, dealIDKey, id)
+}
+
+// DealID returns the deal ID stored in ctx, if any.
+func DealID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(dealIDKey).(string)
+	return id, ok
+}
+
+// WithResourceProviderAddress returns a copy of ctx carrying address as
+// the resource provider this operation concerns.
+func WithResourceProviderAddress(ctx context.Context, address string) context.Context {
+	return context.WithValue(ctx, resourceProviderKey, address)
+}
+
+// ResourceProviderAddress returns the resource provider address stored in
+// ctx, if any.
+func ResourceProviderAddress(ctx context.Context) (string, bool) {
+	address, ok := ctx.Value(resourceProviderKey).(string)
+	return address, ok
+}
+
+// WithRequestID returns a copy of ctx carrying id as the current request
+// ID, for correlating a chain of log lines to one inbound request or
+// connection attempt.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestID returns the request ID stored in ctx, if any.
+func RequestID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey).(string)
+	return id, ok
+}
+
+// WithAuthToken returns a copy of ctx carrying token as the caller's
+// authentication token.
+func WithAuthToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, authTokenKey, token)
+}
+
+// AuthToken returns the auth token stored in ctx, if any.
+func AuthToken(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(authTokenKey).(string)
+	return token, ok
+}
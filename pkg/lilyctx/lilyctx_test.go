@@ -0,0 +1,54 @@
+package lilyctx
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWithJobIDRoundTrip(t *testing.T) {
+	ctx := WithJobID(context.Background(), "job-1")
+
+	id, ok := JobID(ctx)
+	if !ok || id != "job-1" {
+		t.Fatalf("JobID = (%q, %v), want (job-1, true)", id, ok)
+	}
+}
+
+func TestMissingKeysReturnFalse(t *testing.T) {
+	ctx := context.Background()
+
+	if _, ok := JobID(ctx); ok {
+		t.Fatal("JobID should be absent from a bare context")
+	}
+	if _, ok := DealID(ctx); ok {
+		t.Fatal("DealID should be absent from a bare context")
+	}
+	if _, ok := ResourceProviderAddress(ctx); ok {
+		t.Fatal("ResourceProviderAddress should be absent from a bare context")
+	}
+	if _, ok := RequestID(ctx); ok {
+		t.Fatal("RequestID should be absent from a bare context")
+	}
+	if _, ok := AuthToken(ctx); ok {
+		t.Fatal("AuthToken should be absent from a bare context")
+	}
+}
+
+func TestKeysDoNotCollide(t *testing.T) {
+	ctx := WithJobID(context.Background(), "job-1")
+	ctx = WithDealID(ctx, "deal-1")
+	ctx = WithResourceProviderAddress(ctx, "0xrp")
+	ctx = WithRequestID(ctx, "req-1")
+	ctx = WithAuthToken(ctx, "token-1")
+
+	jobID, _ := JobID(ctx)
+	dealID, _ := DealID(ctx)
+	rpAddress, _ := ResourceProviderAddress(ctx)
+	requestID, _ := RequestID(ctx)
+	authToken, _ := AuthToken(ctx)
+
+	if jobID != "job-1" || dealID != "deal-1" || rpAddress != "0xrp" || requestID != "req-1" || authToken != "token-1" {
+		t.Fatalf("got (%q, %q, %q, %q, %q), want (job-1, deal-1, 0xrp, req-1, token-1)",
+			jobID, dealID, rpAddress, requestID, authToken)
+	}
+}
@@ -0,0 +1,42 @@
+package lilylog
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bacalhau-project/lilypad/pkg/lilyctx"
+)
+
+// contextHandler wraps a slog.Handler, pulling the job ID, deal ID,
+// resource provider address and request ID out of a log call's context
+// (via pkg/lilyctx) and attaching them to the record. This is what lets
+// every subsystem log through the same *-Context slog methods and get
+// request-scoped tracing for free, instead of threading these fields
+// through every function signature by hand.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id, ok := lilyctx.RequestID(ctx); ok {
+		record.AddAttrs(slog.String("request_id", id))
+	}
+	if id, ok := lilyctx.JobID(ctx); ok {
+		record.AddAttrs(slog.String("job_id", id))
+	}
+	if id, ok := lilyctx.DealID(ctx); ok {
+		record.AddAttrs(slog.String("deal_id", id))
+	}
+	if address, ok := lilyctx.ResourceProviderAddress(ctx); ok {
+		record.AddAttrs(slog.String("resource_provider", address))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,42 @@
+package lilylog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/bacalhau-project/lilypad/pkg/lilyctx"
+)
+
+func TestContextHandlerAttachesJobID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(contextHandler{slog.NewJSONHandler(&buf, nil)})
+
+	ctx := lilyctx.WithJobID(context.Background(), "job-1")
+	logger.InfoContext(ctx, "submitting job")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("unmarshal log line: %v", err)
+	}
+	if record["job_id"] != "job-1" {
+		t.Fatalf("job_id = %v, want job-1", record["job_id"])
+	}
+}
+
+func TestContextHandlerOmitsAbsentKeys(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(contextHandler{slog.NewJSONHandler(&buf, nil)})
+
+	logger.InfoContext(context.Background(), "no tracing info here")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("unmarshal log line: %v", err)
+	}
+	if _, ok := record["job_id"]; ok {
+		t.Fatal("job_id should not be present without a job ID in context")
+	}
+}
@@ -0,0 +1,96 @@
+// Package lilylog builds the structured loggers every lilypad process
+// uses, with per-subsystem levels configurable via the LILYPAD_LOG
+// environment variable (e.g. "debug,solver=debug,web3=info" sets a debug
+// default and overrides it to info for the web3 subsystem) and a choice
+// of JSON or console encoding via LILYPAD_LOG_FORMAT.
+package lilylog
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Config controls how Registry builds a subsystem's *slog.Logger.
+type Config struct {
+	// Format is "json" or "console".
+	Format string
+	// Default is the level used for subsystems with no override in
+	// Levels.
+	Default slog.Level
+	// Levels maps a subsystem name (as passed to Registry.For) to the
+	// minimum level it logs at.
+	Levels map[string]slog.Level
+}
+
+// NewConfigFromEnv builds a Config from LILYPAD_LOG and LILYPAD_LOG_FORMAT.
+// LILYPAD_LOG is a comma-separated list of "level" (sets the default) and
+// "subsystem=level" (overrides one subsystem) entries; unrecognized
+// entries are ignored so a typo falls back to the default level (info
+// unless LILYPAD_LOG sets another) rather than crashing the process.
+func NewConfigFromEnv() Config {
+	cfg := Config{
+		Format:  os.Getenv("LILYPAD_LOG_FORMAT"),
+		Default: slog.LevelInfo,
+		Levels:  map[string]slog.Level{},
+	}
+	if cfg.Format == "" {
+		cfg.Format = "console"
+	}
+
+	for _, entry := range strings.Split(os.Getenv("LILYPAD_LOG"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		subsystem, levelName, hasSubsystem := strings.Cut(entry, "=")
+		if !hasSubsystem {
+			if level, err := parseLevel(subsystem); err == nil {
+				cfg.Default = level
+			}
+			continue
+		}
+		if level, err := parseLevel(levelName); err == nil {
+			cfg.Levels[subsystem] = level
+		}
+	}
+
+	return cfg
+}
+
+func parseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(name))
+	return level, err
+}
+
+// Registry builds a *slog.Logger per subsystem, honoring Config's levels
+// and encoding.
+type Registry struct {
+	cfg Config
+}
+
+// NewRegistry returns a Registry that builds loggers per cfg.
+func NewRegistry(cfg Config) *Registry {
+	return &Registry{cfg: cfg}
+}
+
+// For returns the *slog.Logger for subsystem, tagged with a "subsystem"
+// attribute and filtered at its configured level (or the registry's
+// default if subsystem has no override).
+func (r *Registry) For(subsystem string) *slog.Logger {
+	level := r.cfg.Default
+	if override, ok := r.cfg.Levels[subsystem]; ok {
+		level = override
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	if r.cfg.Format == "json" {
+		handler = slog.NewJSONHandler(os.Stderr, opts)
+	} else {
+		handler = slog.NewTextHandler(os.Stderr, opts)
+	}
+
+	return slog.New(contextHandler{handler}).With("subsystem", subsystem)
+}
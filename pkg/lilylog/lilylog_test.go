@@ -0,0 +1,41 @@
+package lilylog
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestNewConfigFromEnvParsesDefaultAndOverrides(t *testing.T) {
+	t.Setenv("LILYPAD_LOG", "info,solver=debug,web3=warn")
+	t.Setenv("LILYPAD_LOG_FORMAT", "json")
+
+	cfg := NewConfigFromEnv()
+
+	if cfg.Format != "json" {
+		t.Fatalf("Format = %q, want json", cfg.Format)
+	}
+	if cfg.Default != slog.LevelInfo {
+		t.Fatalf("Default = %v, want info", cfg.Default)
+	}
+	if cfg.Levels["solver"] != slog.LevelDebug {
+		t.Fatalf("Levels[solver] = %v, want debug", cfg.Levels["solver"])
+	}
+	if cfg.Levels["web3"] != slog.LevelWarn {
+		t.Fatalf("Levels[web3] = %v, want warn", cfg.Levels["web3"])
+	}
+}
+
+func TestRegistryForAppliesPerSubsystemLevel(t *testing.T) {
+	registry := NewRegistry(Config{
+		Format:  "console",
+		Default: slog.LevelWarn,
+		Levels:  map[string]slog.Level{"solver": slog.LevelDebug},
+	})
+
+	if !registry.For("solver").Enabled(nil, slog.LevelDebug) {
+		t.Fatal("solver logger should be enabled at debug level")
+	}
+	if registry.For("web3").Enabled(nil, slog.LevelInfo) {
+		t.Fatal("web3 logger should not be enabled at info level when default is warn")
+	}
+}
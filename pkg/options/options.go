@@ -0,0 +1,251 @@
+// Package options centralizes the option structs and their defaults for
+// every lilypad process. Each New*Options constructor fills in defaults
+// from the environment so commands and tests only need to override the
+// handful of fields they actually care about.
+package options
+
+import (
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/bacalhau-project/lilypad/pkg/web3"
+)
+
+// ServerOptions configures the HTTP/WS listener a process exposes.
+type ServerOptions struct {
+	Port int
+	URL  string
+}
+
+// StoreOptions selects and configures the solver's persistence backend.
+type StoreOptions struct {
+	// Driver is one of "memory", "sqlite", "postgres" or "bolt".
+	Driver string
+	// DSN is the driver-specific connection string (a filesystem path for
+	// sqlite/bolt, a connection URL for postgres). Ignored for memory.
+	DSN string
+}
+
+// SolverOptions configures a solver process.
+type SolverOptions struct {
+	Web3   web3.Options
+	Server ServerOptions
+	Store  StoreOptions
+}
+
+// ReconnectOptions configures the exponential-backoff loop a client uses
+// to (re)dial the solver.
+type ReconnectOptions struct {
+	// MinBackoff is the wait before the first reconnect attempt.
+	MinBackoff time.Duration
+	// MaxBackoff caps the wait between reconnect attempts.
+	MaxBackoff time.Duration
+	// MaxRetries bounds how many times a dial will be retried before
+	// giving up. Zero means retry forever.
+	MaxRetries int
+}
+
+// ResourceProviderOptions configures a resource provider process.
+type ResourceProviderOptions struct {
+	Web3      web3.Options
+	SolverURL string
+	Reconnect ReconnectOptions
+	DataTx    DataTxOptions
+}
+
+// JobCreatorOptions configures a job creator process.
+type JobCreatorOptions struct {
+	Web3      web3.Options
+	SolverURL string
+	Reconnect ReconnectOptions
+	DataTx    DataTxOptions
+}
+
+// SimpleTxOptions configures the pkg/datatx/simple backend.
+type SimpleTxOptions struct {
+	// BaseURL is the HTTP PUT/GET endpoint objects are stored under.
+	BaseURL string
+}
+
+// TusTxOptions configures the pkg/datatx/tus backend.
+type TusTxOptions struct {
+	// BaseURL is the tus server's creation endpoint.
+	BaseURL string
+}
+
+// IPFSTxOptions configures the pkg/datatx/ipfs backend.
+type IPFSTxOptions struct {
+	// APIAddress is the IPFS node's HTTP API base.
+	APIAddress string
+}
+
+// S3TxOptions configures the pkg/datatx/s3 backend.
+type S3TxOptions struct {
+	Endpoint        string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	UseSSL          bool
+}
+
+// DataTxOptions configures every pkg/datatx backend a process has
+// credentials for. Unlike StoreOptions, more than one backend can be
+// enabled at once: a deal's metadata picks which one a given job's
+// inputs and results move through, falling back to Default when unset.
+type DataTxOptions struct {
+	// Default is the datatx Manager kind ("simple", "tus", "ipfs" or
+	// "s3") used when a deal carries no explicit "datatx_kind" metadata.
+	Default string
+	Simple  SimpleTxOptions
+	Tus     TusTxOptions
+	IPFS    IPFSTxOptions
+	S3      S3TxOptions
+}
+
+// LogValue delegates to Web3's redacting LogValue so logging a
+// SolverOptions value never leaks its private key.
+func (o SolverOptions) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Any("web3", o.Web3),
+		slog.String("server_url", o.Server.URL),
+		slog.String("store_driver", o.Store.Driver),
+	)
+}
+
+// LogValue delegates to Web3's redacting LogValue so logging a
+// ResourceProviderOptions value never leaks its private key.
+func (o ResourceProviderOptions) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Any("web3", o.Web3),
+		slog.String("solver_url", o.SolverURL),
+		slog.String("datatx_default", o.DataTx.Default),
+	)
+}
+
+// LogValue delegates to Web3's redacting LogValue so logging a
+// JobCreatorOptions value never leaks its private key.
+func (o JobCreatorOptions) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Any("web3", o.Web3),
+		slog.String("solver_url", o.SolverURL),
+		slog.String("datatx_default", o.DataTx.Default),
+	)
+}
+
+func getEnv(name, defaultValue string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvDuration(name string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
+
+func getEnvInt(name string, defaultValue int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
+
+func newReconnectOptions(minEnv, maxEnv, maxRetriesEnv string) ReconnectOptions {
+	return ReconnectOptions{
+		MinBackoff: getEnvDuration(minEnv, 50*time.Millisecond),
+		MaxBackoff: getEnvDuration(maxEnv, 10*time.Second),
+		MaxRetries: getEnvInt(maxRetriesEnv, 0),
+	}
+}
+
+func newDataTxOptions(prefix string) DataTxOptions {
+	return DataTxOptions{
+		Default: getEnv(prefix+"_DATATX_DEFAULT", "simple"),
+		Simple: SimpleTxOptions{
+			BaseURL: getEnv(prefix+"_DATATX_SIMPLE_BASE_URL", ""),
+		},
+		Tus: TusTxOptions{
+			BaseURL: getEnv(prefix+"_DATATX_TUS_BASE_URL", ""),
+		},
+		IPFS: IPFSTxOptions{
+			APIAddress: getEnv(prefix+"_DATATX_IPFS_API_ADDRESS", ""),
+		},
+		S3: S3TxOptions{
+			Endpoint:        getEnv(prefix+"_DATATX_S3_ENDPOINT", ""),
+			Bucket:          getEnv(prefix+"_DATATX_S3_BUCKET", ""),
+			AccessKeyID:     getEnv(prefix+"_DATATX_S3_ACCESS_KEY_ID", ""),
+			SecretAccessKey: getEnv(prefix+"_DATATX_S3_SECRET_ACCESS_KEY", ""),
+			UseSSL:          getEnv(prefix+"_DATATX_S3_USE_SSL", "") == "true",
+		},
+	}
+}
+
+// NewSolverOptions returns SolverOptions populated with defaults, which can
+// then be overridden by the caller.
+func NewSolverOptions() SolverOptions {
+	return SolverOptions{
+		Web3: web3.Options{
+			RpcURL:     getEnv("WEB3_RPC_URL", "http://localhost:8545"),
+			PrivateKey: getEnv("SOLVER_PRIVATE_KEY", ""),
+		},
+		Server: ServerOptions{
+			Port: 8080,
+			URL:  "http://localhost:8080",
+		},
+		Store: StoreOptions{
+			Driver: getEnv("LILYPAD_STORE_DRIVER", "memory"),
+			DSN:    getEnv("LILYPAD_STORE_DSN", ""),
+		},
+	}
+}
+
+// NewResourceProviderOptions returns ResourceProviderOptions populated with
+// defaults, which can then be overridden by the caller.
+func NewResourceProviderOptions() ResourceProviderOptions {
+	return ResourceProviderOptions{
+		Web3: web3.Options{
+			RpcURL:     getEnv("WEB3_RPC_URL", "http://localhost:8545"),
+			PrivateKey: getEnv("RESOURCE_PROVIDER_PRIVATE_KEY", ""),
+		},
+		SolverURL: getEnv("SOLVER_URL", "ws://localhost:8080"),
+		Reconnect: newReconnectOptions(
+			"RESOURCE_PROVIDER_RECONNECT_MIN_BACKOFF",
+			"RESOURCE_PROVIDER_RECONNECT_MAX_BACKOFF",
+			"RESOURCE_PROVIDER_RECONNECT_MAX_RETRIES",
+		),
+		DataTx: newDataTxOptions("RESOURCE_PROVIDER"),
+	}
+}
+
+// NewJobCreatorOptions returns JobCreatorOptions populated with defaults,
+// which can then be overridden by the caller.
+func NewJobCreatorOptions() JobCreatorOptions {
+	return JobCreatorOptions{
+		Web3: web3.Options{
+			RpcURL:     getEnv("WEB3_RPC_URL", "http://localhost:8545"),
+			PrivateKey: getEnv("JOB_CREATOR_PRIVATE_KEY", ""),
+		},
+		SolverURL: getEnv("SOLVER_URL", "ws://localhost:8080"),
+		Reconnect: newReconnectOptions(
+			"JOB_CREATOR_RECONNECT_MIN_BACKOFF",
+			"JOB_CREATOR_RECONNECT_MAX_BACKOFF",
+			"JOB_CREATOR_RECONNECT_MAX_RETRIES",
+		),
+		DataTx: newDataTxOptions("JOB_CREATOR"),
+	}
+}
@@ -0,0 +1,113 @@
+package resourceprovider
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/bacalhau-project/lilypad/pkg/solver"
+)
+
+// AgentOptions describes an execution agent being registered with a
+// ResourceProvider: what it offers and which modules it is willing to
+// run.
+type AgentOptions struct {
+	// Name identifies the agent within this resource provider, e.g.
+	// "gpu-a100" or "docker-sandbox". It must be unique per provider.
+	Name string
+	// Offer is the hardware spec this agent advertises to the solver.
+	Offer solver.Spec
+	// ModuleAllowList restricts which job modules this agent will accept.
+	// An empty list means any module is accepted.
+	ModuleAllowList []string
+}
+
+// Agent is a single named execution target within a ResourceProvider,
+// matched against jobs under the composite identity
+// "<resource-provider-address>.<agent-name>".
+type Agent struct {
+	Name            string
+	Offer           solver.Spec
+	ModuleAllowList []string
+}
+
+// AcceptsModule reports whether the agent's allow-list permits module.
+func (a *Agent) AcceptsModule(module string) bool {
+	if len(a.ModuleAllowList) == 0 {
+		return true
+	}
+	for _, allowed := range a.ModuleAllowList {
+		if allowed == module {
+			return true
+		}
+	}
+	return false
+}
+
+// Identity builds the composite "<resource-provider-address>.<agent-name>"
+// identity job creators target when submitting a deal.
+func Identity(resourceProviderAddress, agentName string) string {
+	return resourceProviderAddress + "." + agentName
+}
+
+// ParseIdentity splits a composite "<resource-provider-address>.<agent-name>"
+// identity back into its parts.
+func ParseIdentity(identity string) (resourceProviderAddress string, agentName string, err error) {
+	parts := strings.SplitN(identity, ".", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("resourceprovider: invalid agent identity %q, want <resource-provider-address>.<agent-name>", identity)
+	}
+	return parts[0], parts[1], nil
+}
+
+// agentRegistry holds the agents a ResourceProvider advertises, keyed by
+// name, guarded by a mutex since agents may be registered and looked up
+// concurrently with the solver connection.
+type agentRegistry struct {
+	mu     sync.RWMutex
+	agents map[string]*Agent
+}
+
+func newAgentRegistry() *agentRegistry {
+	return &agentRegistry{
+		agents: map[string]*Agent{},
+	}
+}
+
+func (r *agentRegistry) register(opts AgentOptions) (*Agent, error) {
+	if opts.Name == "" {
+		return nil, fmt.Errorf("resourceprovider: agent name is required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.agents[opts.Name]; exists {
+		return nil, fmt.Errorf("resourceprovider: agent %q is already registered", opts.Name)
+	}
+
+	agent := &Agent{
+		Name:            opts.Name,
+		Offer:           opts.Offer,
+		ModuleAllowList: opts.ModuleAllowList,
+	}
+	r.agents[opts.Name] = agent
+	return agent, nil
+}
+
+func (r *agentRegistry) get(name string) (*Agent, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	agent, ok := r.agents[name]
+	return agent, ok
+}
+
+func (r *agentRegistry) list() []*Agent {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	agents := make([]*Agent, 0, len(r.agents))
+	for _, agent := range r.agents {
+		agents = append(agents, agent)
+	}
+	return agents
+}
@@ -0,0 +1,43 @@
+package resourceprovider
+
+import "testing"
+
+func TestRegisterAgentRejectsDuplicateName(t *testing.T) {
+	rp := &ResourceProvider{agents: newAgentRegistry()}
+
+	if _, err := rp.RegisterAgent(AgentOptions{Name: "gpu-a100"}); err != nil {
+		t.Fatalf("RegisterAgent: %v", err)
+	}
+	if _, err := rp.RegisterAgent(AgentOptions{Name: "gpu-a100"}); err == nil {
+		t.Fatal("RegisterAgent with a duplicate name should have failed")
+	}
+}
+
+func TestAgentAcceptsModule(t *testing.T) {
+	open := &Agent{Name: "docker-sandbox"}
+	if !open.AcceptsModule("anything") {
+		t.Fatal("agent with no allow-list should accept any module")
+	}
+
+	restricted := &Agent{Name: "gpu-a100", ModuleAllowList: []string{"stable-diffusion"}}
+	if !restricted.AcceptsModule("stable-diffusion") {
+		t.Fatal("agent should accept an allow-listed module")
+	}
+	if restricted.AcceptsModule("llama") {
+		t.Fatal("agent should reject a module not on its allow-list")
+	}
+}
+
+func TestParseIdentity(t *testing.T) {
+	address, agentName, err := ParseIdentity("0xrp.gpu-a100")
+	if err != nil {
+		t.Fatalf("ParseIdentity: %v", err)
+	}
+	if address != "0xrp" || agentName != "gpu-a100" {
+		t.Fatalf("ParseIdentity = (%q, %q), want (0xrp, gpu-a100)", address, agentName)
+	}
+
+	if _, _, err := ParseIdentity("not-composite"); err == nil {
+		t.Fatal("ParseIdentity should reject an identity with no agent name")
+	}
+}
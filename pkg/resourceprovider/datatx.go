@@ -0,0 +1,35 @@
+package resourceprovider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bacalhau-project/lilypad/pkg/datatx"
+	"github.com/bacalhau-project/lilypad/pkg/solver"
+)
+
+// FetchJobInputs downloads every ref in job.Inputs into dir, each through
+// the Manager its own Kind names. It is what the matched agent's executor calls before running a
+// job; see the dispatch TODO in reconnect.go.
+func (rp *ResourceProvider) FetchJobInputs(ctx context.Context, job solver.Job, dir string) ([]string, error) {
+	paths := make([]string, 0, len(job.Inputs))
+	for _, ref := range job.Inputs {
+		path, err := rp.dataTx.Fetch(ctx, ref, dir)
+		if err != nil {
+			return nil, fmt.Errorf("resourceprovider: fetching input for job %s: %w", job.ID, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// PublishResult uploads the file at localPath through the Manager job
+// selects, returning a Ref the job creator can Fetch. It is what the
+// matched agent's executor calls once a job finishes.
+func (rp *ResourceProvider) PublishResult(ctx context.Context, job solver.Job, localPath string) (datatx.Ref, error) {
+	ref, err := rp.dataTx.Publish(ctx, job.DataTxKind, localPath)
+	if err != nil {
+		return datatx.Ref{}, fmt.Errorf("resourceprovider: publishing result for job %s: %w", job.ID, err)
+	}
+	return ref, nil
+}
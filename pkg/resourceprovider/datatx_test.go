@@ -0,0 +1,77 @@
+package resourceprovider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bacalhau-project/lilypad/pkg/datatx"
+	"github.com/bacalhau-project/lilypad/pkg/solver"
+)
+
+// fakeManager is an in-memory datatx.Manager for exercising
+// ResourceProvider's datatx helpers without a real backend.
+type fakeManager struct {
+	blobs map[string][]byte
+}
+
+func (m *fakeManager) Kind() string { return "fake" }
+
+func (m *fakeManager) Publish(ctx context.Context, localPath string) (datatx.Ref, error) {
+	data, err := os.ReadFile(localPath)
+	if err != nil {
+		return datatx.Ref{}, err
+	}
+	location := filepath.Base(localPath)
+	m.blobs[location] = data
+	return datatx.Ref{Kind: m.Kind(), Location: location}, nil
+}
+
+func (m *fakeManager) Fetch(ctx context.Context, ref datatx.Ref, dir string) (string, error) {
+	path := filepath.Join(dir, ref.Location)
+	return path, os.WriteFile(path, m.blobs[ref.Location], 0600)
+}
+
+func TestFetchJobInputsDownloadsEveryRef(t *testing.T) {
+	manager := &fakeManager{blobs: map[string][]byte{"input.bin": []byte("payload")}}
+	rp := &ResourceProvider{dataTx: datatx.NewRegistry("fake", manager)}
+
+	job := solver.Job{ID: "job-1", Inputs: []datatx.Ref{{Kind: "fake", Location: "input.bin"}}}
+	paths, err := rp.FetchJobInputs(context.Background(), job, t.TempDir())
+	if err != nil {
+		t.Fatalf("FetchJobInputs: %v", err)
+	}
+	if len(paths) != 1 {
+		t.Fatalf("FetchJobInputs returned %d paths, want 1", len(paths))
+	}
+	got, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatalf("read fetched file: %v", err)
+	}
+	if string(got) != "payload" {
+		t.Fatalf("fetched content = %q, want %q", got, "payload")
+	}
+}
+
+func TestPublishResultUsesJobDataTxKind(t *testing.T) {
+	manager := &fakeManager{blobs: map[string][]byte{}}
+	rp := &ResourceProvider{dataTx: datatx.NewRegistry("fake", manager)}
+
+	resultPath := filepath.Join(t.TempDir(), "result.bin")
+	if err := os.WriteFile(resultPath, []byte("result"), 0600); err != nil {
+		t.Fatalf("write result file: %v", err)
+	}
+
+	job := solver.Job{ID: "job-1", DataTxKind: "fake"}
+	ref, err := rp.PublishResult(context.Background(), job, resultPath)
+	if err != nil {
+		t.Fatalf("PublishResult: %v", err)
+	}
+	if ref.Kind != "fake" {
+		t.Fatalf("ref.Kind = %q, want fake", ref.Kind)
+	}
+	if string(manager.blobs[ref.Location]) != "result" {
+		t.Fatalf("published blob = %q, want %q", manager.blobs[ref.Location], "result")
+	}
+}
@@ -0,0 +1,98 @@
+package resourceprovider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/bacalhau-project/lilypad/pkg/backoff"
+)
+
+// solverConn is the live websocket connection to the solver. dialSolver
+// opens it; runConnection blocks, re-subscribing to the provider's agent offers and
+// relaying solver events until the connection drops or ctx is done.
+type solverConn struct {
+	ws *websocket.Conn
+}
+
+func (rp *ResourceProvider) dialSolver(ctx context.Context) (*solverConn, error) {
+	ws, _, err := websocket.DefaultDialer.DialContext(ctx, rp.options.SolverURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("resourceprovider: dial solver at %s: %w", rp.options.SolverURL, err)
+	}
+	return &solverConn{ws: ws}, nil
+}
+
+// resubscribe re-announces every registered agent's offer to the solver.
+// It is called once per successful (re)connect, since the solver does not
+// remember offers across a dropped connection.
+func (rp *ResourceProvider) resubscribe(conn *solverConn) error {
+	for _, agent := range rp.agents.list() {
+		if err := conn.ws.WriteJSON(map[string]any{
+			"type":              "offer",
+			"identity":          rp.Identity(agent.Name),
+			"offer":             agent.Offer,
+			"module_allow_list": agent.ModuleAllowList,
+		}); err != nil {
+			return fmt.Errorf("resourceprovider: resubscribe agent %s: %w", agent.Name, err)
+		}
+	}
+	return nil
+}
+
+// runConnection owns a single connection's lifetime: it subscribes, then
+// reads solver events until the socket errors, ctx is cancelled, or the
+// connection is closed deliberately (which returns nil so the reconnect
+// supervisor does not retry).
+func (rp *ResourceProvider) runConnection(ctx context.Context, conn *solverConn) error {
+	defer conn.ws.Close()
+	// Closing the socket once ctx is done unblocks ReadJSON below.
+	stop := context.AfterFunc(ctx, func() { conn.ws.Close() })
+	defer stop()
+
+	if err := rp.resubscribe(conn); err != nil {
+		return err
+	}
+
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+		var event map[string]any
+		if err := conn.ws.ReadJSON(&event); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("resourceprovider: solver connection lost: %w", err)
+		}
+		// TODO: dispatch event to the matched agent's executor. Until that
+		// exists, log so an operator can see a deal/match notification
+		// arrived instead of it vanishing with no trace.
+		rp.log.WarnContext(ctx, "received solver event with no dispatch target yet", "event", event)
+	}
+}
+
+// runReconnectLoop dials the solver and keeps the connection alive,
+// retrying with exponential backoff and jitter whenever it drops. It only
+// returns once ctx is done or the configured retry budget is exhausted.
+// ctx carries the resource provider's address (see pkg/lilyctx) so every
+// log line emitted by a connection attempt is attributed to it.
+func (rp *ResourceProvider) runReconnectLoop(ctx context.Context) error {
+	policy := backoff.Policy{
+		Min:        rp.options.Reconnect.MinBackoff,
+		Max:        rp.options.Reconnect.MaxBackoff,
+		MaxRetries: rp.options.Reconnect.MaxRetries,
+	}
+
+	return policy.Run(ctx, func(ctx context.Context) error {
+		conn, err := rp.dialSolver(ctx)
+		if err != nil {
+			return err
+		}
+		return rp.runConnection(ctx, conn)
+	}, func(attempt int, err error, wait time.Duration) {
+		rp.log.WarnContext(ctx, "solver connection attempt failed, retrying", "attempt", attempt, "wait", wait, "error", err)
+	})
+}
@@ -0,0 +1,155 @@
+package resourceprovider
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/bacalhau-project/lilypad/pkg/options"
+	"github.com/bacalhau-project/lilypad/pkg/web3"
+)
+
+// testPrivateKey is Hardhat's well-known default account #0 key, used
+// only to derive a deterministic address for tests.
+const testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+// TestRunReconnectLoopResubscribesAfterDrop spins up a fake solver
+// websocket that accepts one connection, reads the resubscribe offer,
+// then drops the connection - simulating a solver outage - and asserts
+// the reconnect loop dials again and resubscribes a second time, rather
+// than exiting or wedging.
+func TestRunReconnectLoopResubscribesAfterDrop(t *testing.T) {
+	var connectCount int32
+	offerMessages := make(chan map[string]any, 4)
+
+	// liveConns tracks every upgraded connection so the test can close them
+	// from the server side once it has seen enough resubscribes.
+	var mu sync.Mutex
+	var liveConns []*websocket.Conn
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		mu.Lock()
+		liveConns = append(liveConns, conn)
+		mu.Unlock()
+
+		n := atomic.AddInt32(&connectCount, 1)
+
+		var msg map[string]any
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Errorf("server ReadJSON: %v", err)
+			return
+		}
+		offerMessages <- msg
+
+		if n == 1 {
+			// Drop the connection right after the first resubscribe, so
+			// the reconnect loop has to dial again.
+			return
+		}
+
+		// Stay open on later connections until the test forces it closed.
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	web3SDK, err := web3.NewContractSDK(web3.Options{PrivateKey: testPrivateKey})
+	if err != nil {
+		t.Fatalf("NewContractSDK: %v", err)
+	}
+
+	rp := &ResourceProvider{
+		options: options.ResourceProviderOptions{
+			SolverURL: wsURL,
+			Reconnect: options.ReconnectOptions{
+				MinBackoff: time.Millisecond,
+				MaxBackoff: 10 * time.Millisecond,
+			},
+		},
+		web3SDK: web3SDK,
+		agents:  newAgentRegistry(),
+		log:     slog.Default(),
+	}
+	if _, err := rp.RegisterAgent(AgentOptions{Name: "gpu-a100", ModuleAllowList: []string{"stable-diffusion"}}); err != nil {
+		t.Fatalf("RegisterAgent: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- rp.runReconnectLoop(ctx)
+	}()
+
+	var gotOffers []map[string]any
+	for len(gotOffers) < 2 {
+		select {
+		case msg := <-offerMessages:
+			gotOffers = append(gotOffers, msg)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("resubscribe did not happen twice in time, got %d", len(gotOffers))
+		}
+	}
+
+	for i, msg := range gotOffers {
+		if msg["type"] != "offer" {
+			t.Fatalf("resubscribe %d: type = %v, want offer", i, msg["type"])
+		}
+		if msg["identity"] != rp.Identity("gpu-a100") {
+			t.Fatalf("resubscribe %d: identity = %v, want %s", i, msg["identity"], rp.Identity("gpu-a100"))
+		}
+		allowList, _ := msg["module_allow_list"].([]any)
+		if len(allowList) != 1 || allowList[0] != "stable-diffusion" {
+			t.Fatalf("resubscribe %d: module_allow_list = %v, want [stable-diffusion]", i, msg["module_allow_list"])
+		}
+	}
+
+	// Close the still-open connection and the server before cancelling, so
+	// the loop exits with no solver left to redial.
+	mu.Lock()
+	for _, conn := range liveConns {
+		conn.Close()
+	}
+	mu.Unlock()
+	server.Close()
+
+	cancel()
+	select {
+	case err := <-done:
+		// runConnection treats "ctx done" the same as a deliberate close and
+		// returns nil, so Policy.Run may surface either nil or ctx.Err()
+		// depending on exactly where the cancellation was observed.
+		if err != nil && !errors.Is(err, context.Canceled) {
+			t.Fatalf("runReconnectLoop returned %v after ctx cancel, want nil or context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("runReconnectLoop did not exit after ctx cancel")
+	}
+
+	if got := atomic.LoadInt32(&connectCount); got < 2 {
+		t.Fatalf("solver saw %d connections, want at least 2", got)
+	}
+}
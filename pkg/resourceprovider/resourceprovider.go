@@ -0,0 +1,97 @@
+// Package resourceprovider implements the process that advertises compute
+// offers to the solver and executes matched jobs.
+package resourceprovider
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/bacalhau-project/lilypad/pkg/datatx"
+	"github.com/bacalhau-project/lilypad/pkg/datatx/datatxfactory"
+	"github.com/bacalhau-project/lilypad/pkg/lilyctx"
+	"github.com/bacalhau-project/lilypad/pkg/options"
+	"github.com/bacalhau-project/lilypad/pkg/system"
+	"github.com/bacalhau-project/lilypad/pkg/web3"
+)
+
+// ResourceProvider connects to the solver and runs whatever jobs get
+// matched against the agents registered on it. A single ResourceProvider
+// can register multiple named agents against the one wallet, each
+// advertising its own offer and module allow-list, so one host can serve
+// heterogeneous hardware without running multiple processes.
+type ResourceProvider struct {
+	options options.ResourceProviderOptions
+	web3SDK *web3.ContractSDK
+	agents  *agentRegistry
+	dataTx  *datatx.Registry
+	log     *slog.Logger
+}
+
+// NewResourceProvider wires up a ResourceProvider against the given
+// contract SDK.
+func NewResourceProvider(resourceProviderOptions options.ResourceProviderOptions, web3SDK *web3.ContractSDK) (*ResourceProvider, error) {
+	dataTx, err := datatxfactory.NewRegistry(resourceProviderOptions.DataTx)
+	if err != nil {
+		return nil, fmt.Errorf("resourceprovider: %w", err)
+	}
+	return &ResourceProvider{
+		options: resourceProviderOptions,
+		web3SDK: web3SDK,
+		agents:  newAgentRegistry(),
+		dataTx:  dataTx,
+		log:     slog.Default(),
+	}, nil
+}
+
+// RegisterAgent adds a named execution agent to the provider. It must be
+// called before Start; agents registered after Start has connected to the
+// solver are not yet advertised.
+func (rp *ResourceProvider) RegisterAgent(opts AgentOptions) (*Agent, error) {
+	return rp.agents.register(opts)
+}
+
+// Agents returns every agent currently registered on the provider.
+func (rp *ResourceProvider) Agents() []*Agent {
+	return rp.agents.list()
+}
+
+// Agent returns the named agent, if it has been registered.
+func (rp *ResourceProvider) Agent(name string) (*Agent, bool) {
+	return rp.agents.get(name)
+}
+
+// Address returns the wallet address this provider, and every agent
+// registered on it, is known to the solver as.
+func (rp *ResourceProvider) Address() string {
+	return rp.web3SDK.Address().Hex()
+}
+
+// Identity returns the composite "<address>.<agentName>" identity job
+// creators use to target a specific agent on this provider.
+func (rp *ResourceProvider) Identity(agentName string) string {
+	return Identity(rp.Address(), agentName)
+}
+
+// Start dials the solver, wrapped in a backoff-and-retry reconnect loop,
+// and begins advertising the registered agents' offers. It returns
+// immediately; the reconnect loop runs in the background until
+// systemContext.Ctx is done, at which point systemContext.Cm's registered
+// cleanup waits for it to exit.
+func (rp *ResourceProvider) Start(systemContext *system.CommandContext) error {
+	rp.log = systemContext.Logger("resourceprovider")
+
+	ctx := lilyctx.WithResourceProviderAddress(systemContext.Ctx, rp.Address())
+	rp.log.InfoContext(ctx, "starting resource provider", "solver_url", rp.options.SolverURL)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := rp.runReconnectLoop(ctx); err != nil && ctx.Err() == nil {
+			rp.log.ErrorContext(ctx, "reconnect loop exited", "error", err)
+		}
+	}()
+	systemContext.Cm.RegisterCallback(func() {
+		<-done
+	})
+	return nil
+}
@@ -0,0 +1,49 @@
+// Package solver implements the matching service that pairs submitted
+// jobs with resource provider offers and turns the result into on-chain
+// deals.
+package solver
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/bacalhau-project/lilypad/pkg/options"
+	"github.com/bacalhau-project/lilypad/pkg/system"
+	"github.com/bacalhau-project/lilypad/pkg/web3"
+)
+
+// Solver holds a solver process's options, store and contract SDK.
+type Solver struct {
+	options options.SolverOptions
+	store   Store
+	web3SDK *web3.ContractSDK
+	log     *slog.Logger
+}
+
+// NewSolver wires up a Solver against the given store and contract SDK.
+func NewSolver(solverOptions options.SolverOptions, solverStore Store, web3SDK *web3.ContractSDK) (*Solver, error) {
+	if solverStore == nil {
+		return nil, fmt.Errorf("solver: store is required")
+	}
+	return &Solver{
+		options: solverOptions,
+		store:   solverStore,
+		web3SDK: web3SDK,
+		log:     slog.Default(),
+	}, nil
+}
+
+// Start logs the solver's configuration and registers closing the store
+// with systemContext.Cm. It does not start an HTTP/WS API server or a
+// matching loop.
+func (s *Solver) Start(systemContext *system.CommandContext) error {
+	s.log = systemContext.Logger("solver")
+	s.log.InfoContext(systemContext.Ctx, "starting solver", "url", s.options.Server.URL, "store_driver", s.options.Store.Driver)
+
+	systemContext.Cm.RegisterCallback(func() {
+		if err := s.store.Close(); err != nil {
+			s.log.Error("closing store", "error", err)
+		}
+	})
+	return nil
+}
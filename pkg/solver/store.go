@@ -0,0 +1,42 @@
+package solver
+
+import "context"
+
+// Store is the persistence interface the solver uses to track jobs,
+// matches and deals. Implementations must be safe for concurrent use.
+//
+// Store lives in this package (rather than pkg/solver/store, which holds
+// only ErrNotFound) so that backend packages
+// can import solver for these types and pkg/solver/store for ErrNotFound
+// without pkg/solver ever having to import back down into its own
+// backends' package tree.
+type Store interface {
+	// AddJob inserts a newly submitted job.
+	AddJob(ctx context.Context, job Job) error
+	// GetJob returns the job with the given id.
+	GetJob(ctx context.Context, id string) (Job, error)
+	// UpdateJobState transitions a job to a new state.
+	UpdateJobState(ctx context.Context, id string, state JobState) error
+	// GetOpenJobsMatching returns all jobs in JobStateCreated whose Spec is
+	// compatible with the given spec (module match, CPU/GPU within offer)
+	// and whose TargetIdentity, if set, matches offererIdentity - the
+	// "<resource-provider-address>.<agent-name>" identity of the offer
+	// being matched against.
+	GetOpenJobsMatching(ctx context.Context, spec Spec, offererIdentity string) ([]Job, error)
+
+	// AddMatch records a candidate pairing of a job against an offer.
+	AddMatch(ctx context.Context, match Match) error
+	// GetMatch returns the match with the given id.
+	GetMatch(ctx context.Context, id string) (Match, error)
+
+	// AddDeal confirms a match into a binding deal.
+	AddDeal(ctx context.Context, deal Deal) error
+	// GetDeal returns the deal with the given id.
+	GetDeal(ctx context.Context, id string) (Deal, error)
+	// GetDealsForJob returns every deal struck for a given job id.
+	GetDealsForJob(ctx context.Context, jobID string) ([]Deal, error)
+
+	// Close releases any resources (connections, file handles) held by the
+	// store.
+	Close() error
+}
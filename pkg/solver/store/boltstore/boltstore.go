@@ -0,0 +1,157 @@
+// Package boltstore is a solver.Store backend backed by a single BoltDB
+// file, for single-node deployments that want persistence without
+// standing up a separate database.
+package boltstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/bacalhau-project/lilypad/pkg/solver"
+	"github.com/bacalhau-project/lilypad/pkg/solver/store"
+)
+
+var (
+	bucketJobs    = []byte("jobs")
+	bucketMatches = []byte("matches")
+	bucketDeals   = []byte("deals")
+)
+
+// SolverStoreBolt persists solver state as JSON-encoded values in a
+// BoltDB file at path.
+type SolverStoreBolt struct {
+	db *bolt.DB
+}
+
+// NewSolverStoreBolt opens (creating if necessary) the BoltDB file at
+// path and ensures its buckets exist.
+func NewSolverStoreBolt(path string) (*SolverStoreBolt, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("boltstore: open %s: %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{bucketJobs, bucketMatches, bucketDeals} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("boltstore: migrate: %w", err)
+	}
+	return &SolverStoreBolt{db: db}, nil
+}
+
+func put(db *bolt.DB, bucket []byte, key string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Put([]byte(key), data)
+	})
+}
+
+func get(db *bolt.DB, bucket []byte, key string, out any) error {
+	var data []byte
+	err := db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(bucket).Get([]byte(key))
+		if value == nil {
+			return store.ErrNotFound
+		}
+		data = append([]byte{}, value...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func (s *SolverStoreBolt) AddJob(ctx context.Context, job solver.Job) error {
+	return put(s.db, bucketJobs, job.ID, job)
+}
+
+func (s *SolverStoreBolt) GetJob(ctx context.Context, id string) (solver.Job, error) {
+	var job solver.Job
+	if err := get(s.db, bucketJobs, id, &job); err != nil {
+		return solver.Job{}, err
+	}
+	return job, nil
+}
+
+func (s *SolverStoreBolt) UpdateJobState(ctx context.Context, id string, state solver.JobState) error {
+	job, err := s.GetJob(ctx, id)
+	if err != nil {
+		return err
+	}
+	job.State = state
+	return put(s.db, bucketJobs, id, job)
+}
+
+func (s *SolverStoreBolt) GetOpenJobsMatching(ctx context.Context, spec solver.Spec, offererIdentity string) ([]solver.Job, error) {
+	jobs := []solver.Job{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketJobs).ForEach(func(k, v []byte) error {
+			var job solver.Job
+			if err := json.Unmarshal(v, &job); err != nil {
+				return err
+			}
+			if job.State == solver.JobStateCreated && job.CompatibleWith(spec) && job.MatchesIdentity(offererIdentity) {
+				jobs = append(jobs, job)
+			}
+			return nil
+		})
+	})
+	return jobs, err
+}
+
+func (s *SolverStoreBolt) AddMatch(ctx context.Context, match solver.Match) error {
+	return put(s.db, bucketMatches, match.ID, match)
+}
+
+func (s *SolverStoreBolt) GetMatch(ctx context.Context, id string) (solver.Match, error) {
+	var match solver.Match
+	if err := get(s.db, bucketMatches, id, &match); err != nil {
+		return solver.Match{}, err
+	}
+	return match, nil
+}
+
+func (s *SolverStoreBolt) AddDeal(ctx context.Context, deal solver.Deal) error {
+	return put(s.db, bucketDeals, deal.ID, deal)
+}
+
+func (s *SolverStoreBolt) GetDeal(ctx context.Context, id string) (solver.Deal, error) {
+	var deal solver.Deal
+	if err := get(s.db, bucketDeals, id, &deal); err != nil {
+		return solver.Deal{}, err
+	}
+	return deal, nil
+}
+
+func (s *SolverStoreBolt) GetDealsForJob(ctx context.Context, jobID string) ([]solver.Deal, error) {
+	deals := []solver.Deal{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketDeals).ForEach(func(k, v []byte) error {
+			var deal solver.Deal
+			if err := json.Unmarshal(v, &deal); err != nil {
+				return err
+			}
+			if deal.JobID == jobID {
+				deals = append(deals, deal)
+			}
+			return nil
+		})
+	})
+	return deals, err
+}
+
+func (s *SolverStoreBolt) Close() error {
+	return s.db.Close()
+}
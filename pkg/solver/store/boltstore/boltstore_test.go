@@ -0,0 +1,20 @@
+package boltstore
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bacalhau-project/lilypad/pkg/solver"
+	"github.com/bacalhau-project/lilypad/pkg/solver/store/storetest"
+)
+
+func TestSolverStoreBoltConformance(t *testing.T) {
+	storetest.RunConformanceTests(t, func(t *testing.T) solver.Store {
+		path := filepath.Join(t.TempDir(), "solver.bolt")
+		s, err := NewSolverStoreBolt(path)
+		if err != nil {
+			t.Fatalf("NewSolverStoreBolt: %v", err)
+		}
+		return s
+	})
+}
@@ -0,0 +1,127 @@
+// Package memory is the in-process, non-persistent Store implementation
+// used by tests and local development.
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bacalhau-project/lilypad/pkg/solver"
+	"github.com/bacalhau-project/lilypad/pkg/solver/store"
+)
+
+// SolverStoreMemory keeps all solver state in maps guarded by a mutex. It
+// satisfies solver.Store but loses everything on process restart.
+type SolverStoreMemory struct {
+	mu      sync.Mutex
+	jobs    map[string]solver.Job
+	matches map[string]solver.Match
+	deals   map[string]solver.Deal
+}
+
+// NewSolverStoreMemory returns an empty SolverStoreMemory.
+func NewSolverStoreMemory() (*SolverStoreMemory, error) {
+	return &SolverStoreMemory{
+		jobs:    map[string]solver.Job{},
+		matches: map[string]solver.Match{},
+		deals:   map[string]solver.Deal{},
+	}, nil
+}
+
+func (s *SolverStoreMemory) AddJob(ctx context.Context, job solver.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.ID] = job
+	return nil
+}
+
+func (s *SolverStoreMemory) GetJob(ctx context.Context, id string) (solver.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return solver.Job{}, store.ErrNotFound
+	}
+	return job, nil
+}
+
+func (s *SolverStoreMemory) UpdateJobState(ctx context.Context, id string, state solver.JobState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return store.ErrNotFound
+	}
+	job.State = state
+	s.jobs[id] = job
+	return nil
+}
+
+func (s *SolverStoreMemory) GetOpenJobsMatching(ctx context.Context, spec solver.Spec, offererIdentity string) ([]solver.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	results := []solver.Job{}
+	for _, job := range s.jobs {
+		if job.State != solver.JobStateCreated {
+			continue
+		}
+		if !job.CompatibleWith(spec) {
+			continue
+		}
+		if !job.MatchesIdentity(offererIdentity) {
+			continue
+		}
+		results = append(results, job)
+	}
+	return results, nil
+}
+
+func (s *SolverStoreMemory) AddMatch(ctx context.Context, match solver.Match) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.matches[match.ID] = match
+	return nil
+}
+
+func (s *SolverStoreMemory) GetMatch(ctx context.Context, id string) (solver.Match, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	match, ok := s.matches[id]
+	if !ok {
+		return solver.Match{}, store.ErrNotFound
+	}
+	return match, nil
+}
+
+func (s *SolverStoreMemory) AddDeal(ctx context.Context, deal solver.Deal) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deals[deal.ID] = deal
+	return nil
+}
+
+func (s *SolverStoreMemory) GetDeal(ctx context.Context, id string) (solver.Deal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	deal, ok := s.deals[id]
+	if !ok {
+		return solver.Deal{}, store.ErrNotFound
+	}
+	return deal, nil
+}
+
+func (s *SolverStoreMemory) GetDealsForJob(ctx context.Context, jobID string) ([]solver.Deal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	results := []solver.Deal{}
+	for _, deal := range s.deals {
+		if deal.JobID == jobID {
+			results = append(results, deal)
+		}
+	}
+	return results, nil
+}
+
+func (s *SolverStoreMemory) Close() error {
+	return nil
+}
@@ -0,0 +1,18 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/bacalhau-project/lilypad/pkg/solver"
+	"github.com/bacalhau-project/lilypad/pkg/solver/store/storetest"
+)
+
+func TestSolverStoreMemoryConformance(t *testing.T) {
+	storetest.RunConformanceTests(t, func(t *testing.T) solver.Store {
+		s, err := NewSolverStoreMemory()
+		if err != nil {
+			t.Fatalf("NewSolverStoreMemory: %v", err)
+		}
+		return s
+	})
+}
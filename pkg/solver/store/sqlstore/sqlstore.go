@@ -0,0 +1,238 @@
+// Package sqlstore is a solver.Store backend on top of database/sql,
+// supporting SQLite and Postgres DSNs.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/bacalhau-project/lilypad/pkg/solver"
+	"github.com/bacalhau-project/lilypad/pkg/solver/store"
+)
+
+// SolverStoreSQL persists solver state in a SQL database reached through
+// database/sql. The driver name ("sqlite3" or "postgres") is passed to
+// NewSolverStoreSQL alongside the DSN.
+type SolverStoreSQL struct {
+	db *sql.DB
+}
+
+// NewSolverStoreSQL opens db, running any pending migrations before
+// returning.
+func NewSolverStoreSQL(driverName, dsn string) (*SolverStoreSQL, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("sqlstore: open %s: %w", driverName, err)
+	}
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("sqlstore: ping %s: %w", driverName, err)
+	}
+	s := &SolverStoreSQL{db: db}
+	if err := s.migrate(); err != nil {
+		return nil, fmt.Errorf("sqlstore: migrate: %w", err)
+	}
+	return s, nil
+}
+
+func (s *SolverStoreSQL) migrate() error {
+	_, err := s.db.Exec(`
+CREATE TABLE IF NOT EXISTS jobs (
+	id TEXT PRIMARY KEY,
+	module TEXT NOT NULL,
+	cpu TEXT NOT NULL,
+	gpu TEXT NOT NULL,
+	memory TEXT NOT NULL,
+	creator TEXT NOT NULL,
+	state TEXT NOT NULL,
+	target_identity TEXT NOT NULL DEFAULT '',
+	datatx_kind TEXT NOT NULL DEFAULT '',
+	inputs TEXT NOT NULL DEFAULT '[]',
+	created_at INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_jobs_open_by_spec ON jobs (state, module);
+
+CREATE TABLE IF NOT EXISTS matches (
+	id TEXT PRIMARY KEY,
+	job_id TEXT NOT NULL,
+	resource_provider TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_matches_job_id ON matches (job_id);
+
+CREATE TABLE IF NOT EXISTS deals (
+	id TEXT PRIMARY KEY,
+	job_id TEXT NOT NULL,
+	resource_provider TEXT NOT NULL,
+	created_at INTEGER NOT NULL,
+	metadata TEXT NOT NULL DEFAULT '{}'
+);
+CREATE INDEX IF NOT EXISTS idx_deals_job_id ON deals (job_id);
+`)
+	return err
+}
+
+func (s *SolverStoreSQL) AddJob(ctx context.Context, job solver.Job) error {
+	inputs, err := json.Marshal(job.Inputs)
+	if err != nil {
+		return fmt.Errorf("sqlstore: marshal job inputs: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx, `
+INSERT INTO jobs (id, module, cpu, gpu, memory, creator, state, target_identity, datatx_kind, inputs, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		job.ID, job.Spec.Module, job.Spec.CPU, job.Spec.GPU, job.Spec.Memory,
+		job.Creator, job.State, job.TargetIdentity, job.DataTxKind, inputs, job.CreatedAt)
+	return err
+}
+
+func (s *SolverStoreSQL) GetJob(ctx context.Context, id string) (solver.Job, error) {
+	row := s.db.QueryRowContext(ctx, `
+SELECT id, module, cpu, gpu, memory, creator, state, target_identity, datatx_kind, inputs, created_at FROM jobs WHERE id = ?`, id)
+	job, err := scanJob(row)
+	if err == sql.ErrNoRows {
+		return solver.Job{}, store.ErrNotFound
+	}
+	return job, err
+}
+
+func (s *SolverStoreSQL) UpdateJobState(ctx context.Context, id string, state solver.JobState) error {
+	res, err := s.db.ExecContext(ctx, `UPDATE jobs SET state = ? WHERE id = ?`, state, id)
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// GetOpenJobsMatching narrows down by state, module and target identity
+// in SQL (state and module are covered by idx_jobs_open_by_spec; target
+// identity is not indexed), then applies the CPU/GPU
+// compatibility check in Go via Job.CompatibleWith - the same check
+// memory and boltstore use - since a plain "at least N" comparison on
+// cpu/gpu stored as TEXT doesn't translate into a portable SQL predicate
+// across sqlite and postgres.
+func (s *SolverStoreSQL) GetOpenJobsMatching(ctx context.Context, spec solver.Spec, offererIdentity string) ([]solver.Job, error) {
+	rows, err := s.db.QueryContext(ctx, `
+SELECT id, module, cpu, gpu, memory, creator, state, target_identity, datatx_kind, inputs, created_at
+FROM jobs WHERE state = ? AND module = ? AND (target_identity = '' OR target_identity = ?)`,
+		solver.JobStateCreated, spec.Module, offererIdentity)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []solver.Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		if !job.CompatibleWith(spec) {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// scanJob scans a jobs row, JSON-decoding its inputs column, shared by
+// GetJob and GetOpenJobsMatching the same way scanDeal is shared below.
+func scanJob(row rowScanner) (solver.Job, error) {
+	var job solver.Job
+	var inputs []byte
+	if err := row.Scan(&job.ID, &job.Spec.Module, &job.Spec.CPU, &job.Spec.GPU, &job.Spec.Memory,
+		&job.Creator, &job.State, &job.TargetIdentity, &job.DataTxKind, &inputs, &job.CreatedAt); err != nil {
+		return solver.Job{}, err
+	}
+	if err := json.Unmarshal(inputs, &job.Inputs); err != nil {
+		return solver.Job{}, fmt.Errorf("sqlstore: unmarshal job inputs: %w", err)
+	}
+	return job, nil
+}
+
+func (s *SolverStoreSQL) AddMatch(ctx context.Context, match solver.Match) error {
+	_, err := s.db.ExecContext(ctx, `
+INSERT INTO matches (id, job_id, resource_provider) VALUES (?, ?, ?)`,
+		match.ID, match.JobID, match.ResourceProvider)
+	return err
+}
+
+func (s *SolverStoreSQL) GetMatch(ctx context.Context, id string) (solver.Match, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT id, job_id, resource_provider FROM matches WHERE id = ?`, id)
+	var match solver.Match
+	err := row.Scan(&match.ID, &match.JobID, &match.ResourceProvider)
+	if err == sql.ErrNoRows {
+		return solver.Match{}, store.ErrNotFound
+	}
+	if err != nil {
+		return solver.Match{}, err
+	}
+	return match, nil
+}
+
+func (s *SolverStoreSQL) AddDeal(ctx context.Context, deal solver.Deal) error {
+	metadata, err := json.Marshal(deal.Metadata)
+	if err != nil {
+		return fmt.Errorf("sqlstore: marshal deal metadata: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx, `
+INSERT INTO deals (id, job_id, resource_provider, created_at, metadata) VALUES (?, ?, ?, ?, ?)`,
+		deal.ID, deal.JobID, deal.ResourceProvider, deal.CreatedAt, metadata)
+	return err
+}
+
+func (s *SolverStoreSQL) GetDeal(ctx context.Context, id string) (solver.Deal, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT id, job_id, resource_provider, created_at, metadata FROM deals WHERE id = ?`, id)
+	deal, err := scanDeal(row)
+	if err == sql.ErrNoRows {
+		return solver.Deal{}, store.ErrNotFound
+	}
+	return deal, err
+}
+
+func (s *SolverStoreSQL) GetDealsForJob(ctx context.Context, jobID string) ([]solver.Deal, error) {
+	rows, err := s.db.QueryContext(ctx, `
+SELECT id, job_id, resource_provider, created_at, metadata FROM deals WHERE job_id = ?`, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deals := []solver.Deal{}
+	for rows.Next() {
+		deal, err := scanDeal(rows)
+		if err != nil {
+			return nil, err
+		}
+		deals = append(deals, deal)
+	}
+	return deals, rows.Err()
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows, letting GetDeal
+// and GetDealsForJob share the same metadata-decoding scan logic.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanDeal(row rowScanner) (solver.Deal, error) {
+	var deal solver.Deal
+	var metadata []byte
+	if err := row.Scan(&deal.ID, &deal.JobID, &deal.ResourceProvider, &deal.CreatedAt, &metadata); err != nil {
+		return solver.Deal{}, err
+	}
+	if err := json.Unmarshal(metadata, &deal.Metadata); err != nil {
+		return solver.Deal{}, fmt.Errorf("sqlstore: unmarshal deal metadata: %w", err)
+	}
+	return deal, nil
+}
+
+func (s *SolverStoreSQL) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,15 @@
+// Package store holds the shared pieces of the solver persistence
+// contract (solver.Store, implemented by memory/sqlstore/boltstore) that
+// don't belong to any one backend. The Store interface itself lives in
+// pkg/solver as solver.Store - this package only exists so backends have
+// a common, solver-independent home for ErrNotFound and the conformance
+// suite in storetest.
+package store
+
+// ErrNotFound is returned by Get* methods when no record exists for the
+// requested id.
+var ErrNotFound = storeError("store: not found")
+
+type storeError string
+
+func (e storeError) Error() string { return string(e) }
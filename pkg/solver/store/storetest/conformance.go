@@ -0,0 +1,184 @@
+// Package storetest is the shared conformance suite every solver.Store
+// backend must pass. It is kept out of the store package itself so that
+// plain testing imports don't leak into production binaries.
+package storetest
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/bacalhau-project/lilypad/pkg/datatx"
+	"github.com/bacalhau-project/lilypad/pkg/solver"
+	"github.com/bacalhau-project/lilypad/pkg/solver/store"
+)
+
+// RunConformanceTests exercises the Store contract against newStore, which
+// must return a fresh, empty backend instance each time it is called.
+// Every backend under pkg/solver/store/ should have a _test.go that calls
+// this from a TestXxx function so the suite only has to be written once.
+func RunConformanceTests(t *testing.T, newStore func(t *testing.T) solver.Store) {
+	ctx := context.Background()
+
+	t.Run("job round trip", func(t *testing.T) {
+		s := newStore(t)
+		defer s.Close()
+
+		job := solver.Job{
+			ID:         "job-1",
+			Spec:       solver.Spec{Module: "stable-diffusion", CPU: "2", GPU: "1"},
+			Creator:    "0xcreator",
+			State:      solver.JobStateCreated,
+			DataTxKind: "ipfs",
+			Inputs:     []datatx.Ref{{Kind: "ipfs", Location: "Qm123"}},
+		}
+		if err := s.AddJob(ctx, job); err != nil {
+			t.Fatalf("AddJob: %v", err)
+		}
+
+		got, err := s.GetJob(ctx, job.ID)
+		if err != nil {
+			t.Fatalf("GetJob: %v", err)
+		}
+		if !reflect.DeepEqual(got, job) {
+			t.Fatalf("GetJob = %+v, want %+v", got, job)
+		}
+
+		if err := s.UpdateJobState(ctx, job.ID, solver.JobStateMatched); err != nil {
+			t.Fatalf("UpdateJobState: %v", err)
+		}
+		got, err = s.GetJob(ctx, job.ID)
+		if err != nil {
+			t.Fatalf("GetJob after update: %v", err)
+		}
+		if got.State != solver.JobStateMatched {
+			t.Fatalf("job state = %s, want %s", got.State, solver.JobStateMatched)
+		}
+	})
+
+	t.Run("get missing job", func(t *testing.T) {
+		s := newStore(t)
+		defer s.Close()
+
+		if _, err := s.GetJob(ctx, "does-not-exist"); err != store.ErrNotFound {
+			t.Fatalf("GetJob error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("open jobs matching spec", func(t *testing.T) {
+		s := newStore(t)
+		defer s.Close()
+
+		matching := solver.Job{ID: "job-a", Spec: solver.Spec{Module: "sdxl"}, State: solver.JobStateCreated}
+		other := solver.Job{ID: "job-b", Spec: solver.Spec{Module: "llama"}, State: solver.JobStateCreated}
+		taken := solver.Job{ID: "job-c", Spec: solver.Spec{Module: "sdxl"}, State: solver.JobStateDone}
+
+		for _, job := range []solver.Job{matching, other, taken} {
+			if err := s.AddJob(ctx, job); err != nil {
+				t.Fatalf("AddJob(%s): %v", job.ID, err)
+			}
+		}
+
+		jobs, err := s.GetOpenJobsMatching(ctx, solver.Spec{Module: "sdxl"}, "")
+		if err != nil {
+			t.Fatalf("GetOpenJobsMatching: %v", err)
+		}
+		if len(jobs) != 1 || jobs[0].ID != matching.ID {
+			t.Fatalf("GetOpenJobsMatching = %+v, want only %s", jobs, matching.ID)
+		}
+	})
+
+	t.Run("open jobs matching CPU/GPU capacity", func(t *testing.T) {
+		s := newStore(t)
+		defer s.Close()
+
+		fits := solver.Job{ID: "job-fits", Spec: solver.Spec{Module: "sdxl", CPU: "2", GPU: "1"}, State: solver.JobStateCreated}
+		tooMuchCPU := solver.Job{ID: "job-too-much-cpu", Spec: solver.Spec{Module: "sdxl", CPU: "8", GPU: "1"}, State: solver.JobStateCreated}
+		tooMuchGPU := solver.Job{ID: "job-too-much-gpu", Spec: solver.Spec{Module: "sdxl", CPU: "2", GPU: "4"}, State: solver.JobStateCreated}
+
+		for _, job := range []solver.Job{fits, tooMuchCPU, tooMuchGPU} {
+			if err := s.AddJob(ctx, job); err != nil {
+				t.Fatalf("AddJob(%s): %v", job.ID, err)
+			}
+		}
+
+		jobs, err := s.GetOpenJobsMatching(ctx, solver.Spec{Module: "sdxl", CPU: "2", GPU: "1"}, "")
+		if err != nil {
+			t.Fatalf("GetOpenJobsMatching: %v", err)
+		}
+		if len(jobs) != 1 || jobs[0].ID != fits.ID {
+			t.Fatalf("GetOpenJobsMatching = %+v, want only %s", jobs, fits.ID)
+		}
+	})
+
+	t.Run("open jobs matching targeted identity", func(t *testing.T) {
+		s := newStore(t)
+		defer s.Close()
+
+		open := solver.Job{ID: "job-open", Spec: solver.Spec{Module: "sdxl"}, State: solver.JobStateCreated}
+		targeted := solver.Job{ID: "job-targeted", Spec: solver.Spec{Module: "sdxl"}, State: solver.JobStateCreated, TargetIdentity: "0xrp.gpu-a100"}
+		targetedElsewhere := solver.Job{ID: "job-elsewhere", Spec: solver.Spec{Module: "sdxl"}, State: solver.JobStateCreated, TargetIdentity: "0xrp.cpu-fast"}
+
+		for _, job := range []solver.Job{open, targeted, targetedElsewhere} {
+			if err := s.AddJob(ctx, job); err != nil {
+				t.Fatalf("AddJob(%s): %v", job.ID, err)
+			}
+		}
+
+		jobs, err := s.GetOpenJobsMatching(ctx, solver.Spec{Module: "sdxl"}, "0xrp.gpu-a100")
+		if err != nil {
+			t.Fatalf("GetOpenJobsMatching: %v", err)
+		}
+
+		gotIDs := map[string]bool{}
+		for _, job := range jobs {
+			gotIDs[job.ID] = true
+		}
+		if !gotIDs[open.ID] || !gotIDs[targeted.ID] || gotIDs[targetedElsewhere.ID] {
+			t.Fatalf("GetOpenJobsMatching(%q) = %+v, want %s and %s but not %s",
+				"0xrp.gpu-a100", jobs, open.ID, targeted.ID, targetedElsewhere.ID)
+		}
+	})
+
+	t.Run("match and deal round trip", func(t *testing.T) {
+		s := newStore(t)
+		defer s.Close()
+
+		match := solver.Match{ID: "match-1", JobID: "job-1", ResourceProvider: "0xrp"}
+		if err := s.AddMatch(ctx, match); err != nil {
+			t.Fatalf("AddMatch: %v", err)
+		}
+		gotMatch, err := s.GetMatch(ctx, match.ID)
+		if err != nil {
+			t.Fatalf("GetMatch: %v", err)
+		}
+		if gotMatch != match {
+			t.Fatalf("GetMatch = %+v, want %+v", gotMatch, match)
+		}
+
+		deal := solver.Deal{
+			ID:               "deal-1",
+			JobID:            "job-1",
+			ResourceProvider: "0xrp",
+			Metadata:         map[string]string{"datatx_kind": "simple"},
+		}
+		if err := s.AddDeal(ctx, deal); err != nil {
+			t.Fatalf("AddDeal: %v", err)
+		}
+		gotDeal, err := s.GetDeal(ctx, deal.ID)
+		if err != nil {
+			t.Fatalf("GetDeal: %v", err)
+		}
+		if !reflect.DeepEqual(gotDeal, deal) {
+			t.Fatalf("GetDeal = %+v, want %+v", gotDeal, deal)
+		}
+
+		deals, err := s.GetDealsForJob(ctx, "job-1")
+		if err != nil {
+			t.Fatalf("GetDealsForJob: %v", err)
+		}
+		if len(deals) != 1 || deals[0].ID != deal.ID {
+			t.Fatalf("GetDealsForJob = %+v, want only %s", deals, deal.ID)
+		}
+	})
+}
@@ -0,0 +1,34 @@
+// Package storefactory builds a solver.Store from options.StoreOptions, so
+// callers (the solver command, tests) don't need to know about every
+// backend package.
+package storefactory
+
+import (
+	"fmt"
+
+	"github.com/bacalhau-project/lilypad/pkg/options"
+	"github.com/bacalhau-project/lilypad/pkg/solver"
+	"github.com/bacalhau-project/lilypad/pkg/solver/store/boltstore"
+	"github.com/bacalhau-project/lilypad/pkg/solver/store/memory"
+	"github.com/bacalhau-project/lilypad/pkg/solver/store/sqlstore"
+
+	// SQL drivers registered for use with sqlstore.
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// NewStore builds the solver.Store named by storeOptions.Driver.
+func NewStore(storeOptions options.StoreOptions) (solver.Store, error) {
+	switch storeOptions.Driver {
+	case "", "memory":
+		return memory.NewSolverStoreMemory()
+	case "sqlite":
+		return sqlstore.NewSolverStoreSQL("sqlite3", storeOptions.DSN)
+	case "postgres":
+		return sqlstore.NewSolverStoreSQL("postgres", storeOptions.DSN)
+	case "bolt":
+		return boltstore.NewSolverStoreBolt(storeOptions.DSN)
+	default:
+		return nil, fmt.Errorf("storefactory: unknown store driver %q", storeOptions.Driver)
+	}
+}
@@ -0,0 +1,125 @@
+package solver
+
+import (
+	"strconv"
+
+	"github.com/bacalhau-project/lilypad/pkg/datatx"
+)
+
+// Spec describes the module and hardware requirements of a job, and is
+// the shape jobs are matched against when a resource provider's offer is
+// checked for compatibility.
+type Spec struct {
+	Module string
+	CPU    string
+	GPU    string
+	Memory string
+}
+
+// CompatibleWith reports whether a job requiring this Spec can run
+// against a resource provider offer advertising offerSpec's capacity: the
+// module must match exactly, and this Spec's CPU/GPU requirements - plain
+// integer counts, e.g. "2" cores or "1" GPU - must each fit within
+// offerSpec's. An empty or zero requirement is always satisfied; a
+// requirement that isn't a plain integer never is.
+func (s Spec) CompatibleWith(offerSpec Spec) bool {
+	return s.Module == offerSpec.Module &&
+		fitsWithin(s.CPU, offerSpec.CPU) &&
+		fitsWithin(s.GPU, offerSpec.GPU)
+}
+
+// fitsWithin reports whether the plain integer requirement fits within
+// the plain integer available capacity. An empty or zero requirement
+// always fits; a non-numeric requirement or capacity never does.
+func fitsWithin(required, available string) bool {
+	if required == "" {
+		return true
+	}
+	req, err := strconv.Atoi(required)
+	if err != nil {
+		return false
+	}
+	if req <= 0 {
+		return true
+	}
+	avail, err := strconv.Atoi(available)
+	if err != nil {
+		return false
+	}
+	return req <= avail
+}
+
+// JobState is the lifecycle state of a Job as tracked by the solver.
+type JobState string
+
+const (
+	JobStateCreated JobState = "created"
+	JobStateMatched JobState = "matched"
+	JobStateRunning JobState = "running"
+	JobStateResults JobState = "results"
+	JobStateDone    JobState = "done"
+	JobStateError   JobState = "error"
+)
+
+// Job is a unit of work submitted by a job creator, waiting to be matched
+// against a resource provider's offer.
+type Job struct {
+	ID      string
+	Spec    Spec
+	Creator string
+	State   JobState
+	// TargetIdentity, if set, restricts matching to the resource provider
+	// agent advertising this exact "<resource-provider-address>.<agent-name>"
+	// identity. Empty means any compatible offer may match.
+	TargetIdentity string
+	// DataTxKind optionally pins the pkg/datatx Manager kind ("simple",
+	// "tus", "ipfs" or "s3") used to move this job's inputs and results.
+	// It is carried onto the Deal's Metadata once matched; empty defers
+	// to the datatx.Registry's configured default.
+	DataTxKind string
+	// Inputs are the refs produced by publishing this job's input files
+	// through DataTxKind (or the default Manager) at submission time. A
+	// resource provider that wins the job fetches them via
+	// ResourceProvider.FetchJobInputs.
+	Inputs    []datatx.Ref
+	CreatedAt int64
+}
+
+// MatchesIdentity reports whether a job can be matched against an offer
+// advertised under offererIdentity: either the job has no target (open
+// market) or the target is an exact match.
+func (j Job) MatchesIdentity(offererIdentity string) bool {
+	return j.TargetIdentity == "" || j.TargetIdentity == offererIdentity
+}
+
+// CompatibleWith reports whether the job can be matched against a
+// resource provider offer advertising offerSpec's capacity. See
+// Spec.CompatibleWith.
+func (j Job) CompatibleWith(offerSpec Spec) bool {
+	return j.Spec.CompatibleWith(offerSpec)
+}
+
+// Deal is the agreement struck between a Job and a resource provider once
+// the solver has matched them.
+type Deal struct {
+	ID    string
+	JobID string
+	// ResourceProvider is the identity of the agent that won the job: a
+	// bare wallet address, or a "<resource-provider-address>.<agent-name>"
+	// composite identity when the provider runs multiple agents.
+	ResourceProvider string
+	CreatedAt        int64
+	// Metadata carries deal-scoped configuration that doesn't affect
+	// matching, such as which pkg/datatx manager ("datatx_kind") the job
+	// creator and resource provider should use to move this deal's inputs
+	// and results. Nil is equivalent to empty.
+	Metadata map[string]string
+}
+
+// Match is a candidate pairing of a Job against a resource provider offer,
+// produced by the solver's matching loop before it is confirmed as a Deal.
+type Match struct {
+	ID               string
+	JobID            string
+	ResourceProvider string
+}
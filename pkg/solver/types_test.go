@@ -0,0 +1,28 @@
+package solver
+
+import "testing"
+
+func TestSpecCompatibleWith(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  Spec
+		offer Spec
+		want  bool
+	}{
+		{"module mismatch", Spec{Module: "sdxl"}, Spec{Module: "llama"}, false},
+		{"fits exactly", Spec{Module: "sdxl", CPU: "2", GPU: "1"}, Spec{Module: "sdxl", CPU: "2", GPU: "1"}, true},
+		{"fits under capacity", Spec{Module: "sdxl", CPU: "2", GPU: "1"}, Spec{Module: "sdxl", CPU: "8", GPU: "4"}, true},
+		{"CPU exceeds capacity", Spec{Module: "sdxl", CPU: "8", GPU: "1"}, Spec{Module: "sdxl", CPU: "2", GPU: "1"}, false},
+		{"GPU exceeds capacity", Spec{Module: "sdxl", CPU: "2", GPU: "4"}, Spec{Module: "sdxl", CPU: "2", GPU: "1"}, false},
+		{"no requirement always fits", Spec{Module: "sdxl"}, Spec{Module: "sdxl"}, true},
+		{"non-numeric requirement never fits", Spec{Module: "sdxl", CPU: "lots"}, Spec{Module: "sdxl", CPU: "2"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.spec.CompatibleWith(test.offer); got != test.want {
+				t.Fatalf("CompatibleWith = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
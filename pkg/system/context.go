@@ -0,0 +1,75 @@
+// Package system provides the shared runtime plumbing (context, cleanup,
+// lifecycle) that every lilypad process - solver, resource provider and job
+// creator - is built on top of.
+package system
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bacalhau-project/lilypad/pkg/lilylog"
+)
+
+// CleanupManager collects shutdown functions and runs them in LIFO order.
+// It is intentionally tiny: components register a closer when they start up
+// and CommandContext.Cleanup runs them all when the process is done.
+type CleanupManager struct {
+	fns []func()
+}
+
+// NewCleanupManager returns an empty CleanupManager.
+func NewCleanupManager() *CleanupManager {
+	return &CleanupManager{}
+}
+
+// RegisterCallback adds fn to the set of functions run on cleanup.
+func (cm *CleanupManager) RegisterCallback(fn func()) {
+	cm.fns = append(cm.fns, fn)
+}
+
+// Cleanup runs every registered callback in reverse registration order.
+func (cm *CleanupManager) Cleanup() {
+	for i := len(cm.fns) - 1; i >= 0; i-- {
+		cm.fns[i]()
+	}
+}
+
+// CommandContext bundles the context.Context and CleanupManager that every
+// top level command (solver, resource-provider, job-creator, CLI
+// subcommands) threads through its Start methods.
+type CommandContext struct {
+	Ctx    context.Context
+	Cancel context.CancelFunc
+	Cm     *CleanupManager
+	Log    *lilylog.Registry
+}
+
+// NewCommandContext derives a cancellable CommandContext from parent, with
+// a log registry configured from LILYPAD_LOG/LILYPAD_LOG_FORMAT.
+func NewCommandContext(parent context.Context) *CommandContext {
+	ctx, cancel := context.WithCancel(parent)
+	return &CommandContext{
+		Ctx:    ctx,
+		Cancel: cancel,
+		Cm:     NewCleanupManager(),
+		Log:    lilylog.NewRegistry(lilylog.NewConfigFromEnv()),
+	}
+}
+
+// Logger returns the *slog.Logger for subsystem, per c.Log's configured
+// per-subsystem levels.
+func (c *CommandContext) Logger(subsystem string) *slog.Logger {
+	return c.Log.For(subsystem)
+}
+
+// NewTestingContext returns a CommandContext rooted in context.Background,
+// suitable for integration tests that need to start a full stack.
+func NewTestingContext() *CommandContext {
+	return NewCommandContext(context.Background())
+}
+
+// Cleanup cancels the context and runs every registered cleanup callback.
+func (c *CommandContext) Cleanup() {
+	c.Cancel()
+	c.Cm.Cleanup()
+}
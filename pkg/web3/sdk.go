@@ -0,0 +1,70 @@
+// Package web3 wraps the on-chain contract calls (job/deal/result
+// escrow, payment) that the solver, resource provider and job creator all
+// need to make against the lilypad smart contracts.
+package web3
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Options configures the connection to the chain a ContractSDK talks to.
+type Options struct {
+	RpcURL     string
+	PrivateKey string
+}
+
+// LogValue redacts PrivateKey so logging an Options value (directly, or
+// nested inside a SolverOptions/ResourceProviderOptions/JobCreatorOptions)
+// never leaks the secret.
+func (o Options) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("rpc_url", o.RpcURL),
+		slog.String("private_key", redactSecret(o.PrivateKey)),
+	)
+}
+
+func redactSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "REDACTED"
+}
+
+// ContractSDK is the handle used to read and write the lilypad contracts.
+// A real implementation dials an RPC endpoint and loads the contract
+// bindings; this is the seam the rest of the codebase programs against.
+type ContractSDK struct {
+	options Options
+	address common.Address
+}
+
+// NewContractSDK validates options and returns a ContractSDK whose address
+// is derived from options.PrivateKey. It does not dial options.RpcURL.
+func NewContractSDK(options Options) (*ContractSDK, error) {
+	if options.PrivateKey == "" {
+		return nil, fmt.Errorf("web3: private key is required")
+	}
+	privateKey, err := crypto.HexToECDSA(options.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("web3: invalid private key: %w", err)
+	}
+	return &ContractSDK{
+		options: options,
+		address: crypto.PubkeyToAddress(privateKey.PublicKey),
+	}, nil
+}
+
+// Options returns the Options the SDK was constructed with.
+func (sdk *ContractSDK) Options() Options {
+	return sdk.options
+}
+
+// Address returns the wallet address derived from the SDK's configured
+// private key - the identity this process is known to the solver as.
+func (sdk *ContractSDK) Address() common.Address {
+	return sdk.address
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -10,13 +11,12 @@ import (
 	optionsfactory "github.com/bacalhau-project/lilypad/pkg/options"
 	"github.com/bacalhau-project/lilypad/pkg/resourceprovider"
 	"github.com/bacalhau-project/lilypad/pkg/solver"
-	solvermemorystore "github.com/bacalhau-project/lilypad/pkg/solver/store/memory"
+	"github.com/bacalhau-project/lilypad/pkg/solver/storefactory"
 	"github.com/bacalhau-project/lilypad/pkg/system"
 	"github.com/bacalhau-project/lilypad/pkg/web3"
-	"github.com/davecgh/go-spew/spew"
 )
 
-func getSolver(t *testing.T, systemContext *system.CommandContext) (*solver.Solver, error) {
+func getSolverWithStore(t *testing.T, systemContext *system.CommandContext, solverStore solver.Store) (*solver.Solver, error) {
 	solverOptions := optionsfactory.NewSolverOptions()
 	solverOptions.Web3.PrivateKey = os.Getenv("SOLVER_PRIVATE_KEY")
 	solverOptions.Server.Port = 8080
@@ -27,19 +27,22 @@ func getSolver(t *testing.T, systemContext *system.CommandContext) (*solver.Solv
 		return nil, fmt.Errorf("SOLVER_PRIVATE_KEY is not defined")
 	}
 
-	spew.Dump(solverOptions)
+	systemContext.Logger("solver").Debug("resolved solver options", "options", solverOptions)
 
 	web3SDK, err := web3.NewContractSDK(solverOptions.Web3)
 	if err != nil {
 		return nil, err
 	}
 
-	solverStore, err := solvermemorystore.NewSolverStoreMemory()
+	return solver.NewSolver(solverOptions, solverStore, web3SDK)
+}
+
+func getSolver(t *testing.T, systemContext *system.CommandContext) (*solver.Solver, error) {
+	solverStore, err := storefactory.NewStore(optionsfactory.StoreOptions{Driver: "memory"})
 	if err != nil {
 		return nil, err
 	}
-
-	return solver.NewSolver(solverOptions, solverStore, web3SDK)
+	return getSolverWithStore(t, systemContext, solverStore)
 }
 
 func getResourceProvider(t *testing.T, systemContext *system.CommandContext) (*resourceprovider.ResourceProvider, error) {
@@ -48,8 +51,12 @@ func getResourceProvider(t *testing.T, systemContext *system.CommandContext) (*r
 	if resourceProviderOptions.Web3.PrivateKey == "" {
 		return nil, fmt.Errorf("RESOURCE_PROVIDER_PRIVATE_KEY is not defined")
 	}
+	// keep reconnect tests fast: retry almost immediately instead of the
+	// 50ms-10s production backoff
+	resourceProviderOptions.Reconnect.MinBackoff = time.Millisecond
+	resourceProviderOptions.Reconnect.MaxBackoff = 10 * time.Millisecond
 
-	spew.Dump(resourceProviderOptions)
+	systemContext.Logger("resourceprovider").Debug("resolved resource provider options", "options", resourceProviderOptions)
 
 	web3SDK, err := web3.NewContractSDK(resourceProviderOptions.Web3)
 	if err != nil {
@@ -66,7 +73,7 @@ func getJobCreator(t *testing.T, systemContext *system.CommandContext) (*jobcrea
 		return nil, fmt.Errorf("JOB_CREATOR_PRIVATE_KEY is not defined")
 	}
 
-	spew.Dump(jobCreatorOptions)
+	systemContext.Logger("jobcreator").Debug("resolved job creator options", "options", jobCreatorOptions)
 
 	web3SDK, err := web3.NewContractSDK(jobCreatorOptions.Web3)
 	if err != nil {
@@ -86,7 +93,7 @@ func TestStack(t *testing.T) {
 		return
 	}
 
-	err = solver.Start(commandCtx.Ctx, commandCtx.Cm)
+	err = solver.Start(commandCtx)
 	if err != nil {
 		t.Error(err)
 		return
@@ -98,11 +105,54 @@ func TestStack(t *testing.T) {
 		return
 	}
 
-	err = resourceProvider.Start(commandCtx.Ctx, commandCtx.Cm)
+	err = resourceProvider.Start(commandCtx)
 	if err != nil {
 		t.Error(err)
 		return
 	}
 
 	time.Sleep(time.Second * 60)
+}
+
+// TestSolverPersistsAcrossRestart checks that a job written through a
+// BoltDB-backed store survives a restart: the same BoltDB file is
+// reopened as a second store instance and must still see the job the
+// first instance wrote.
+func TestSolverPersistsAcrossRestart(t *testing.T) {
+	commandCtx := system.NewTestingContext()
+	defer commandCtx.Cleanup()
+
+	dsn := filepath.Join(t.TempDir(), "solver.bolt")
+
+	firstStore, err := storefactory.NewStore(optionsfactory.StoreOptions{Driver: "bolt", DSN: dsn})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job := solver.Job{
+		ID:      "persisted-job",
+		Spec:    solver.Spec{Module: "stable-diffusion"},
+		Creator: "0xcreator",
+		State:   solver.JobStateCreated,
+	}
+	if err := firstStore.AddJob(commandCtx.Ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	if err := firstStore.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	secondStore, err := storefactory.NewStore(optionsfactory.StoreOptions{Driver: "bolt", DSN: dsn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer secondStore.Close()
+
+	got, err := secondStore.GetJob(commandCtx.Ctx, job.ID)
+	if err != nil {
+		t.Fatalf("job did not survive restart: %v", err)
+	}
+	if got.ID != job.ID {
+		t.Fatalf("GetJob = %+v, want %+v", got, job)
+	}
 }
\ No newline at end of file